@@ -0,0 +1,79 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"syscall"
+)
+
+// DevFull emulates the UNIX /dev/full: reads return zeros, and every
+// write fails with ENOSPC (no space left on device).
+//
+// It is safe for concurrent use.
+type DevFull struct {
+	DevZero
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewDevFull creates an emulation of /dev/full that supports the
+// io.Reader, io.Writer and io.Closer interfaces.
+func NewDevFull() *DevFull {
+	retval := DevFull{}
+
+	// all done
+	return &retval
+}
+
+// ================================================================
+//
+// io.Writer interface
+//
+// ----------------------------------------------------------------
+
+// Write emulates /dev/full: all writes fail with syscall.ENOSPC (or with
+// io.ErrClosedPipe, if you have called Close).
+func (d *DevFull) Write(p []byte) (int, error) {
+	if d.isClosed() {
+		return 0, io.ErrClosedPipe
+	}
+
+	return 0, syscall.ENOSPC
+}
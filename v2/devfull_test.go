@@ -0,0 +1,183 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+func TestNewDevFullWorks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit := NewDevFull()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, unit)
+}
+
+// ================================================================
+//
+// Interface compatibility
+//
+// ----------------------------------------------------------------
+
+func TestDevFullImplementsIOReadWriteCloser(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevFull()
+	var i interface{} = unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(io.ReadWriteCloser)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+func TestDevFullReadZerosTheInputBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevFull()
+
+	expectedOutput := make([]byte, 10)
+
+	actualOutput := make([]byte, 10)
+	for i := range actualOutput {
+		actualOutput[i] = 255
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.Read(actualOutput)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 10, actualLen)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+// ================================================================
+//
+// io.Writer interface
+//
+// ----------------------------------------------------------------
+
+func TestDevFullWriteReturnsENOSPC(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevFull()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.Write([]byte("hello world"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, 0, actualLen)
+	assert.ErrorIs(t, err, syscall.ENOSPC)
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+func TestDevFullCloseShutsDownAdditionalReadsAndWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevFull()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	readLen, readErr := unit.Read(make([]byte, 10))
+	writeLen, writeErr := unit.Write([]byte("hello world"))
+
+	assert.Equal(t, 0, readLen)
+	assert.Equal(t, io.ErrClosedPipe, readErr)
+	assert.Equal(t, 0, writeLen)
+	assert.Equal(t, io.ErrClosedPipe, writeErr)
+}
@@ -36,12 +36,15 @@ package ioextra
 
 import (
 	"io"
+	"sync/atomic"
 )
 
 // DevNull emulates UNIX /dev/null behaviour, with full support as
 // io.Reader, io.Closer and io.Writer.
+//
+// It is safe for concurrent use.
 type DevNull struct {
-	flags int
+	flags atomic.Int32
 }
 
 // ================================================================
@@ -52,12 +55,23 @@ type DevNull struct {
 
 // NewDevNull creates an emulation of /dev/null.
 func NewDevNull() *DevNull {
-	retval := DevNull{flags: 0}
+	retval := DevNull{}
 
 	// all done
 	return &retval
 }
 
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// isClosed returns true if Close() has been called.
+func (d *DevNull) isClosed() bool {
+	return d.flags.Load()&closed != 0
+}
+
 // ================================================================
 //
 // io.Reader interface
@@ -66,7 +80,7 @@ func NewDevNull() *DevNull {
 
 // Read emulates /dev/null: it returns zero bytes.
 func (d *DevNull) Read(b []byte) (int, error) {
-	if d.flags&closed == 0 {
+	if !d.isClosed() {
 		return 0, io.EOF
 	}
 
@@ -81,7 +95,7 @@ func (d *DevNull) Read(b []byte) (int, error) {
 
 // Close prevents all further reads and writes.
 func (d *DevNull) Close() error {
-	d.flags |= closed
+	d.flags.Or(closed)
 	return nil
 }
 
@@ -94,7 +108,7 @@ func (d *DevNull) Close() error {
 // Write emulates /dev/null: all writes succeed (as long as you haven't
 // called Close) but do nothing.
 func (d *DevNull) Write(p []byte) (int, error) {
-	if d.flags&closed == 0 {
+	if !d.isClosed() {
 		return io.Discard.Write(p)
 	}
 	return 0, io.ErrClosedPipe
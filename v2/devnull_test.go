@@ -36,6 +36,7 @@ package ioextra
 
 import (
 	"io"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -275,3 +276,43 @@ func TestDevNullWritePretendsTheWriteWorked(t *testing.T) {
 	// data you expected it to
 	assert.Equal(t, expectedLen, actualLen)
 }
+
+// ================================================================
+//
+// Concurrency
+//
+// ----------------------------------------------------------------
+
+func TestDevNullSupportsConcurrentCloseAndWrite(t *testing.T) {
+	// this test is only meaningful when run with `go test -race`
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevNull()
+	testData := []byte("hello world!")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			unit.Write(testData)
+		}()
+		go func() {
+			defer wg.Done()
+			unit.Close()
+		}()
+	}
+	wg.Wait()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	_, err := unit.Write(testData)
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
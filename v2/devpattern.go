@@ -0,0 +1,150 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"sync"
+)
+
+// UnlimitedLength tells NewDevPattern() (and friends) to return data
+// forever, instead of returning io.EOF after a fixed number of bytes.
+const UnlimitedLength int64 = -1
+
+// DevPattern is a generalised DevZero: it fills every read with a
+// repeating byte pattern, such as a single byte or a line of text.
+//
+// It can be given a total length, after which it returns io.EOF. This
+// makes it ideal for feeding a known-size synthetic input into code
+// under test, or into benchmarks.
+//
+// Writes are discarded, just like DevNull.
+//
+// It is safe for concurrent use.
+type DevPattern struct {
+	DevNull
+
+	mu sync.Mutex
+
+	// the bytes that we repeat
+	pattern []byte
+
+	// where we are in the pattern
+	offset int
+
+	// how many bytes we have left to return, or UnlimitedLength
+	remaining int64
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewDevPattern creates a DevPattern that repeats the given pattern.
+//
+// It returns io.EOF after `length` bytes have been read. Set `length` to
+// UnlimitedLength to read forever.
+//
+// An empty pattern is treated as a single 0x00 byte, the same as DevZero.
+func NewDevPattern(pattern []byte, length int64) *DevPattern {
+	if len(pattern) == 0 {
+		pattern = []byte{0}
+	}
+
+	retval := DevPattern{
+		pattern:   append([]byte(nil), pattern...),
+		remaining: length,
+	}
+
+	// all done
+	return &retval
+}
+
+// NewDevPatternByte creates a DevPattern that fills every read with
+// the given byte.
+func NewDevPatternByte(b byte, length int64) *DevPattern {
+	return NewDevPattern([]byte{b}, length)
+}
+
+// NewDevPatternLine creates a DevPattern that repeats the given line of
+// text. We add a trailing "\n" if the line does not already have one.
+func NewDevPatternLine(line string, length int64) *DevPattern {
+	if len(line) == 0 || line[len(line)-1] != '\n' {
+		line += "\n"
+	}
+
+	return NewDevPattern([]byte(line), length)
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+// Read fills the byte slice that we have been given with our repeating
+// pattern. It returns io.EOF once we have reached our total length.
+func (d *DevPattern) Read(b []byte) (int, error) {
+	if d.isClosed() {
+		return 0, io.ErrClosedPipe
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.remaining == 0 {
+		return 0, io.EOF
+	}
+
+	// do we need to make a short read?
+	if d.remaining > 0 && int64(len(b)) > d.remaining {
+		b = b[:d.remaining]
+	}
+
+	n := 0
+	for n < len(b) {
+		copied := copy(b[n:], d.pattern[d.offset:])
+		n += copied
+		d.offset = (d.offset + copied) % len(d.pattern)
+	}
+
+	if d.remaining > 0 {
+		d.remaining -= int64(n)
+	}
+
+	return n, nil
+}
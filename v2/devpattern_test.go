@@ -0,0 +1,275 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+func TestNewDevPatternWorks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit := NewDevPattern([]byte("abc"), UnlimitedLength)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, unit)
+}
+
+func TestNewDevPatternLineAddsMissingLineTerminator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevPatternLine("hello", 12)
+	expectedResult := "hello\nhello\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := io.ReadAll(unit)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, string(actualResult))
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+func TestDevPatternReadFillsTheBufferWithTheChosenByte(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevPatternByte('x', UnlimitedLength)
+	expectedOutput := []byte("xxxxxxxxxx")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput := make([]byte, 10)
+	actualLen, err := unit.Read(actualOutput)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 10, actualLen)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestDevPatternReadContinuesThePatternAcrossReads(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevPattern([]byte("abc"), UnlimitedLength)
+	expectedOutput := "abcabcabcab"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	buf1 := make([]byte, 4)
+	buf2 := make([]byte, 7)
+	unit.Read(buf1)
+	unit.Read(buf2)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedOutput, string(buf1)+string(buf2))
+}
+
+func TestDevPatternReadReturnsEOFAfterTheTotalLength(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevPattern([]byte("abc"), 5)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	buf := make([]byte, 10)
+	actualLen, err := unit.Read(buf)
+	eofLen, eofErr := unit.Read(buf)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 5, actualLen)
+	assert.Equal(t, "abcab", string(buf[:actualLen]))
+	assert.Equal(t, 0, eofLen)
+	assert.Equal(t, io.EOF, eofErr)
+}
+
+func TestDevPatternEmptyPatternBehavesLikeDevZero(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevPattern(nil, 4)
+	expectedOutput := make([]byte, 4)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput, err := io.ReadAll(unit)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+func TestDevPatternCloseShutsDownAdditionalReads(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevPatternByte('x', UnlimitedLength)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	actualLen, err := unit.Read(make([]byte, 10))
+
+	assert.Equal(t, 0, actualLen)
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
+
+// ================================================================
+//
+// TextDevPattern
+//
+// ----------------------------------------------------------------
+
+func TestTextDevPatternReadLinesReturnsEachRepeatedLine(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextDevPatternLine("hello world", 36)
+	expectedResult := []string{"hello world", "hello world", "hello world"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := []string{}
+	for line := range unit.ReadLines() {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+// ================================================================
+//
+// Benchmarks
+//
+// ----------------------------------------------------------------
+
+func BenchmarkTextIOWrapperReadLines(b *testing.B) {
+	const lineLen = 64
+	const numLines = 10000
+
+	line := "the quick brown fox jumps over the lazy dog 0123456789 abcdefgh"
+	b.SetBytes(lineLen * numLines)
+
+	for i := 0; i < b.N; i++ {
+		unit := NewTextDevPatternLine(line, lineLen*numLines)
+		for range unit.ReadLines() {
+		}
+	}
+}
+
+func BenchmarkTextIOWrapperReadWords(b *testing.B) {
+	const lineLen = 64
+	const numLines = 10000
+
+	line := "the quick brown fox jumps over the lazy dog 0123456789 abcdefgh"
+	b.SetBytes(lineLen * numLines)
+
+	for i := 0; i < b.N; i++ {
+		unit := NewTextDevPatternLine(line, lineLen*numLines)
+		for range unit.ReadWords() {
+		}
+	}
+}
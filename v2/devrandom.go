@@ -0,0 +1,94 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"encoding/binary"
+	"io"
+	"math/rand/v2"
+	"sync"
+)
+
+// DevRandom emulates the UNIX /dev/random, using a seeded pseudo-random
+// number generator. The same seed always produces the same bytes, which
+// makes it ideal for repeatable tests.
+//
+// It is NOT suitable for cryptography. Use DevURandom for that.
+//
+// It is safe for concurrent use.
+type DevRandom struct {
+	DevNull
+
+	mu  sync.Mutex
+	rng *rand.ChaCha8
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewDevRandom creates an emulation of /dev/random that supports the
+// io.Reader, io.Writer and io.Closer interfaces. It returns the same
+// stream of bytes for the same seed.
+func NewDevRandom(seed uint64) *DevRandom {
+	var chachaSeed [32]byte
+	binary.LittleEndian.PutUint64(chachaSeed[:], seed)
+
+	retval := DevRandom{rng: rand.NewChaCha8(chachaSeed)}
+
+	// all done
+	return &retval
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+// Read fills the byte slice that we have been given with pseudo-random
+// bytes.
+func (d *DevRandom) Read(b []byte) (int, error) {
+	if d.isClosed() {
+		return 0, io.ErrClosedPipe
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rng.Read(b)
+}
@@ -0,0 +1,202 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+func TestNewDevRandomWorks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit := NewDevRandom(42)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, unit)
+}
+
+// ================================================================
+//
+// Interface compatibility
+//
+// ----------------------------------------------------------------
+
+func TestDevRandomImplementsIOReadWriteCloser(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevRandom(42)
+	var i interface{} = unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(io.ReadWriteCloser)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+func TestDevRandomReadIsRepeatableForTheSameSeed(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit1 := NewDevRandom(42)
+	unit2 := NewDevRandom(42)
+
+	expectedOutput := make([]byte, 64)
+	actualOutput := make([]byte, 64)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err1 := io.ReadFull(unit1, expectedOutput)
+	_, err2 := io.ReadFull(unit2, actualOutput)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, expectedOutput, actualOutput)
+	assert.NotEqual(t, make([]byte, 64), actualOutput)
+}
+
+func TestDevRandomReadDependsOnTheSeed(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit1 := NewDevRandom(42)
+	unit2 := NewDevRandom(43)
+
+	output1 := make([]byte, 64)
+	output2 := make([]byte, 64)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	io.ReadFull(unit1, output1)
+	io.ReadFull(unit2, output2)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotEqual(t, output1, output2)
+}
+
+// ================================================================
+//
+// io.Writer interface
+//
+// ----------------------------------------------------------------
+
+func TestDevRandomWriteDiscardsTheData(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevRandom(42)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.Write([]byte("hello world"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 11, actualLen)
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+func TestDevRandomCloseShutsDownAdditionalReads(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewDevRandom(42)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	actualLen, err := unit.Read(make([]byte, 10))
+
+	assert.Equal(t, 0, actualLen)
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
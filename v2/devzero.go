@@ -39,6 +39,8 @@ import (
 )
 
 // DevZero emulates the UNIX /dev/zero.
+//
+// It is safe for concurrent use.
 type DevZero struct {
 	DevNull
 }
@@ -67,7 +69,7 @@ func NewDevZero() *DevZero {
 // Read emulates /dev/zero: it zeros the byte slice that we have
 // been given.
 func (d *DevZero) Read(b []byte) (int, error) {
-	if d.flags&closed != 0 {
+	if d.isClosed() {
 		return 0, io.ErrClosedPipe
 	}
 
@@ -0,0 +1,65 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import "iter"
+
+// LineWriter represents an output destination that accepts whole lines
+// of text, and formatted text.
+type LineWriter interface {
+	// Printf writes formatted text to the output destination, just like
+	// fmt.Fprintf() does.
+	Printf(format string, a ...interface{}) (int, error)
+
+	// Println writes its operands to the output destination, separated
+	// by spaces and followed by the line terminator.
+	Println(a ...interface{}) (int, error)
+
+	// WriteLine writes the given line to the output destination, followed
+	// by the line terminator.
+	WriteLine(line string) (int, error)
+
+	// WriteLines writes each of the given lines to the output destination,
+	// each followed by the line terminator.
+	WriteLines(lines []string) (int, error)
+
+	// WriteLinesFrom writes each line received from the given channel to
+	// the output destination, each followed by the line terminator.
+	WriteLinesFrom(lines <-chan string) (int, error)
+
+	// WriteLinesSeq writes each line from the given iterator to the
+	// output destination, each followed by the line terminator.
+	WriteLinesSeq(lines iter.Seq[string]) (int, error)
+}
@@ -35,12 +35,41 @@
 package ioextra
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
 )
 
 // TextBuffer is a bytes.Buffer with full TextReader / TextWriter support.
 type TextBuffer struct {
 	bytes.Buffer
+
+	// scanOptions controls the bufio.Scanner used by ReadLines() and
+	// ReadWords()
+	scanOptions ScanOptions
+
+	// lineEnding controls what our read methods do with the end of
+	// each line
+	lineEnding LineEnding
+
+	// lineTerminator is what WriteLine() adds to the end of each line
+	lineTerminator string
+
+	// utf8Policy tells us what to do with invalid UTF-8
+	utf8Policy UTF8Policy
+
+	// validator applies our UTF-8 policy to everything that we read,
+	// when we are not using UTF8PassThrough
+	validator *utf8BufferReader
+
+	// encoder applies our UTF-8 policy to everything that we write,
+	// when we are not using UTF8PassThrough
+	encoder *encodingWriter
 }
 
 // ================================================================
@@ -58,12 +87,159 @@ func NewTextBuffer() *TextBuffer {
 	return &retval
 }
 
+// ================================================================
+//
+// Settings
+//
+// ----------------------------------------------------------------
+
+// SetScanOptions sets the buffer sizes, and the policy for tokens that
+// are too long, used by ReadLines(), ReadWords() and Strings().
+func (d *TextBuffer) SetScanOptions(opts ScanOptions) {
+	d.scanOptions = opts
+}
+
+// SetLineEnding sets what ReadLine(), ReadLines(), Lines() and Strings()
+// do with the end of each line.
+func (d *TextBuffer) SetLineEnding(ending LineEnding) {
+	d.lineEnding = ending
+}
+
+// SetLineTerminator sets what WriteLine() adds to the end of each line.
+// Use an empty string to go back to DefaultLineTerminator.
+func (d *TextBuffer) SetLineTerminator(terminator string) {
+	d.lineTerminator = terminator
+}
+
+// SetUTF8Policy sets what our read and write methods do when they find
+// invalid UTF-8: pass it through untouched (the default), replace it, or
+// return an EncodingError.
+//
+// Our read methods treat the buffer's contents as complete: an incomplete
+// UTF-8 character at the end of the buffer is invalid.
+func (d *TextBuffer) SetUTF8Policy(policy UTF8Policy) {
+	d.utf8Policy = policy
+	d.validator = nil
+	d.encoder = nil
+}
+
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// bufferSource is what our read methods need from the underlying
+// bytes.Buffer, or from whatever checks its contents first
+type bufferSource interface {
+	io.Reader
+	io.ByteScanner
+	io.RuneScanner
+}
+
+// textSource returns where our read methods get their data from: the
+// underlying bytes.Buffer, or something that checks its contents first.
+func (d *TextBuffer) textSource() bufferSource {
+	if d.utf8Policy == UTF8PassThrough {
+		return &d.Buffer
+	}
+
+	if d.validator == nil {
+		d.validator = newUTF8BufferReader(&d.Buffer, d.utf8Policy)
+	}
+
+	return d.validator
+}
+
+// peekSource returns something that lets us look at the data that our
+// read methods will get next, without consuming it.
+func (d *TextBuffer) peekSource() bytePeeker {
+	if d.utf8Policy == UTF8PassThrough {
+		return newUTF8BufferReader(&d.Buffer, UTF8PassThrough)
+	}
+
+	return d.textSource().(*utf8BufferReader)
+}
+
+// textEncoder returns the encodingWriter that applies our UTF-8 policy
+// to everything that we write. Only use it when we are not using
+// UTF8PassThrough.
+func (d *TextBuffer) textEncoder() *encodingWriter {
+	if d.encoder == nil {
+		d.encoder = newEncodingWriter(&d.Buffer, EncodingUTF8, false, d.utf8Policy)
+	}
+
+	return d.encoder
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+// Read reads up to len(p) bytes from our buffer, applying the policy set
+// by SetUTF8Policy().
+func (d *TextBuffer) Read(p []byte) (int, error) {
+	return d.textSource().Read(p)
+}
+
+// WriteTo writes the remaining data in our buffer to w. It is here to
+// stop io.Copy() from bypassing the policy set by SetUTF8Policy().
+func (d *TextBuffer) WriteTo(w io.Writer) (int64, error) {
+	if d.utf8Policy == UTF8PassThrough {
+		return d.Buffer.WriteTo(w)
+	}
+
+	return io.Copy(w, d.textSource())
+}
+
 // ================================================================
 //
 // TextReader
 //
 // ----------------------------------------------------------------
 
+// Floats returns an iterator that you can `range` over to get each
+// remaining whitespace-separated number from our buffer, as a float64.
+// Tokens that are not numbers are returned with a *TokenError.
+func (d *TextBuffer) Floats() iter.Seq2[float64, error] {
+	return parseTokens(d.WordsWithErrors(), parseFloat64)
+}
+
+// Ints returns an iterator that you can `range` over to get each
+// remaining whitespace-separated number from our buffer, as an int.
+// Tokens that are not integers are returned with a *TokenError.
+func (d *TextBuffer) Ints() iter.Seq2[int, error] {
+	return parseTokens(d.WordsWithErrors(), strconv.Atoi)
+}
+
+// Lines returns an iterator that you can `range` over to get each
+// remaining line from our buffer. It stops at the first read error.
+func (d *TextBuffer) Lines() iter.Seq[string] {
+	return withoutErrors(d.LinesWithErrors())
+}
+
+// LinesWithErrors returns an iterator that you can `range` over to get
+// each remaining line from our buffer, and any read error.
+func (d *TextBuffer) LinesWithErrors() iter.Seq2[string, error] {
+	return newReadIterator(func() (string, error) {
+		return ReadLineWithEnding(d.textSource(), d.lineEnding.iteratorEnding())
+	})
+}
+
+// ParseBool returns the next line in our buffer as a bool.
+//
+// See ParseBool() for the values that it accepts.
+func (d *TextBuffer) ParseBool() (bool, error) {
+	return ParseBool(d)
+}
+
+// ParseFloat returns the next line in our buffer as a float64.
+func (d *TextBuffer) ParseFloat() (float64, error) {
+	return ParseFloat(d)
+}
+
 // ParseInt returns the data in our buffer as an integer.
 //
 // If the buffer contains anything other than a valid number, an error
@@ -72,22 +248,199 @@ func (d *TextBuffer) ParseInt() (int, error) {
 	return ParseInt(d)
 }
 
+// ParseInt64 returns the next line in our buffer as a base 10 int64.
+func (d *TextBuffer) ParseInt64() (int64, error) {
+	return ParseInt64(d)
+}
+
+// ParseIntBase returns the next line in our buffer as an int64 in the
+// given base.
+//
+// Use base 0 to support 0x, 0o and 0b prefixes, and underscores between
+// digits.
+func (d *TextBuffer) ParseIntBase(base int) (int64, error) {
+	return ParseIntBase(d, base)
+}
+
+// ParseUint returns the next line in our buffer as a base 10 uint64.
+func (d *TextBuffer) ParseUint() (uint64, error) {
+	return ParseUint(d)
+}
+
+// ParseUintBase returns the next line in our buffer as a uint64 in the
+// given base.
+//
+// Use base 0 to support 0x, 0o and 0b prefixes, and underscores between
+// digits.
+func (d *TextBuffer) ParseUintBase(base int) (uint64, error) {
+	return ParseUintBase(d, base)
+}
+
+// ReadAllString returns all of the remaining data in our buffer as a
+// single string. Unlike String(), it returns any read error instead of
+// panicking.
+func (d *TextBuffer) ReadAllString() (string, error) {
+	return ReadAllString(d)
+}
+
+// ReadAllStrings returns all of the remaining data in our buffer as an
+// array of strings, one line per array entry. Unlike Strings(), it also
+// returns any read error.
+func (d *TextBuffer) ReadAllStrings() ([]string, error) {
+	return collectStrings(d.ReadLinesWithOptions(context.Background(), d.scanOptions))
+}
+
+// ReadAllTrimmed returns all of the remaining data in our buffer as a
+// string, with any leading or trailing whitespace removed. Unlike
+// TrimmedString(), it returns any read error instead of panicking.
+func (d *TextBuffer) ReadAllTrimmed() (string, error) {
+	return ReadAllTrimmed(d)
+}
+
+// ReadDelimited returns a channel that you can `range` over to get each
+// `sep`-separated record from our buffer. The separator is not
+// included in the records.
+func (d *TextBuffer) ReadDelimited(sep string) <-chan string {
+	return d.ReadRecords(NewDelimiterSplitFunc(sep))
+}
+
+// ReadFloats returns a channel that you can `range` over to get each
+// remaining whitespace-separated number from our buffer, as a float64.
+// Tokens that are not numbers are sent with a *TokenError.
+func (d *TextBuffer) ReadFloats() <-chan FloatResult {
+	chn, _ := d.ReadFloatsContext(context.Background())
+	return chn
+}
+
+// ReadFloatsContext works just like ReadFloats(), but stops when the given
+// context is cancelled. See ReadFloatsContext() for details.
+func (d *TextBuffer) ReadFloatsContext(ctx context.Context) (<-chan FloatResult, func() error) {
+	return floatResults(ctx, d.Floats())
+}
+
+// ReadInts returns a channel that you can `range` over to get each
+// remaining whitespace-separated number from our buffer, as an int.
+// Tokens that are not integers are sent with a *TokenError.
+func (d *TextBuffer) ReadInts() <-chan IntResult {
+	chn, _ := d.ReadIntsContext(context.Background())
+	return chn
+}
+
+// ReadIntsContext works just like ReadInts(), but stops when the given
+// context is cancelled. See ReadIntsContext() for details.
+func (d *TextBuffer) ReadIntsContext(ctx context.Context) (<-chan IntResult, func() error) {
+	return intResults(ctx, d.Ints())
+}
+
 // ReadLine returns the next line of data from our buffer, or an error
 // if a problem was encountered.
 func (d *TextBuffer) ReadLine() (string, error) {
-	return ReadLine(d)
+	return ReadLineWithEnding(d.textSource(), d.lineEnding)
 }
 
 // ReadLines returns a channel that you can `range` over to get each
 // line from our buffer
 func (d *TextBuffer) ReadLines() <-chan string {
-	return ReadLines(d)
+	chn, _ := d.ReadLinesWithOptions(context.Background(), d.scanOptions)
+	return chn
+}
+
+// ReadLinesContext returns a channel that you can `range` over to get each
+// remaining line from our buffer, until the given context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextBuffer) ReadLinesContext(ctx context.Context) (<-chan string, func() error) {
+	return d.ReadLinesWithOptions(ctx, d.scanOptions)
+}
+
+// ReadLinesWithOptions works just like ReadLinesContext(), but uses the
+// given ScanOptions instead of the ones set by SetScanOptions().
+func (d *TextBuffer) ReadLinesWithOptions(ctx context.Context, opts ScanOptions) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, d, d.lineEnding.SplitFunc(), opts)
+}
+
+// ReadParagraphs returns a channel that you can `range` over to get each
+// paragraph from our buffer. Paragraphs are blocks of lines,
+// separated by blank lines.
+//
+// Each paragraph is sent as an array of lines, without their line
+// endings. Use ParagraphOptions to decide which lines count as blank, and
+// whether or not they are kept.
+func (d *TextBuffer) ReadParagraphs(opts ParagraphOptions) <-chan []string {
+	chn, _ := d.ReadParagraphsContext(context.Background(), opts)
+	return chn
+}
+
+// ReadParagraphsContext works just like ReadParagraphs(), until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextBuffer) ReadParagraphsContext(ctx context.Context, opts ParagraphOptions) (<-chan []string, func() error) {
+	return ReadParagraphsWithOptions(ctx, d, opts, d.scanOptions)
+}
+
+// ReadParagraphsJoined works just like ReadParagraphs(), but sends each
+// paragraph as a single string. The lines of the paragraph are joined by
+// their original line endings.
+func (d *TextBuffer) ReadParagraphsJoined(opts ParagraphOptions) <-chan string {
+	return d.ReadRecords(NewParagraphSplitFunc(opts))
+}
+
+// ReadRecords returns a channel that you can `range` over to get each
+// record from our buffer, as found by the given
+// bufio.SplitFunc (eg, ScanNUL, ScanParagraphs or bufio.ScanRunes).
+func (d *TextBuffer) ReadRecords(split bufio.SplitFunc) <-chan string {
+	chn, _ := d.ReadRecordsContext(context.Background(), split)
+	return chn
+}
+
+// ReadRecordsContext works just like ReadRecords(), until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextBuffer) ReadRecordsContext(ctx context.Context, split bufio.SplitFunc) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, d, split, d.scanOptions)
 }
 
 // ReadWords returns a channel that you can `range` over to get each
 // word from our buffer
 func (d *TextBuffer) ReadWords() <-chan string {
-	return ReadWords(d)
+	chn, _ := ReadWordsWithOptions(context.Background(), d, d.scanOptions)
+	return chn
+}
+
+// ReadWordsContext returns a channel that you can `range` over to get each
+// remaining word from our buffer, until the given context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextBuffer) ReadWordsContext(ctx context.Context) (<-chan string, func() error) {
+	return ReadWordsWithOptions(ctx, d, d.scanOptions)
+}
+
+// ReadWordsWithOptions works just like ReadWordsContext(), but uses the
+// given ScanOptions instead of the ones set by SetScanOptions().
+func (d *TextBuffer) ReadWordsWithOptions(ctx context.Context, opts ScanOptions) (<-chan string, func() error) {
+	return ReadWordsWithOptions(ctx, d, opts)
+}
+
+// ScanLine reads the next line from our buffer, and decodes its
+// whitespace-separated fields into the given destinations.
+//
+// See ScanLine() for the supported destinations.
+func (d *TextBuffer) ScanLine(dest ...interface{}) error {
+	return ScanLine(d, dest...)
+}
+
+// ScanLineDelimited reads the next line from our buffer, and decodes its
+// sep-separated fields into the given destinations.
+//
+// See ScanLine() for the supported destinations.
+func (d *TextBuffer) ScanLineDelimited(sep string, dest ...interface{}) error {
+	return ScanLineDelimited(d, sep, dest...)
 }
 
 // String returns all the remaining data in our buffer as a single string.
@@ -106,3 +459,160 @@ func (d *TextBuffer) Strings() []string {
 func (d *TextBuffer) TrimmedString() string {
 	return TrimmedString(d)
 }
+
+// Words returns an iterator that you can `range` over to get each
+// remaining word from our buffer. It stops at the first read error.
+func (d *TextBuffer) Words() iter.Seq[string] {
+	return withoutErrors(d.WordsWithErrors())
+}
+
+// WordsWithErrors returns an iterator that you can `range` over to get
+// each remaining word from our buffer, and any read error.
+func (d *TextBuffer) WordsWithErrors() iter.Seq2[string, error] {
+	return newReadIterator(func() (string, error) {
+		return readWord(d.textSource())
+	})
+}
+
+// ================================================================
+//
+// TextPeeker interface
+//
+// ----------------------------------------------------------------
+
+// PeekLine returns the next line of data from our buffer, without
+// consuming it. It returns the same line that ReadLine() would.
+func (d *TextBuffer) PeekLine() (string, error) {
+	return peekLineWithEnding(d.peekSource(), d.lineEnding)
+}
+
+// PeekRune returns the next character from our buffer, and its size in
+// bytes, without consuming it.
+func (d *TextBuffer) PeekRune() (rune, int, error) {
+	return peekRune(d.peekSource())
+}
+
+// ReadRune returns the next character from our buffer, and its size in
+// bytes.
+func (d *TextBuffer) ReadRune() (rune, int, error) {
+	return d.textSource().ReadRune()
+}
+
+// UnreadRune puts the last character returned by ReadRune() back into
+// our buffer. It must be called straight after ReadRune().
+func (d *TextBuffer) UnreadRune() error {
+	return d.textSource().UnreadRune()
+}
+
+// ================================================================
+//
+// TextWriter
+//
+// ----------------------------------------------------------------
+
+// Write appends the contents of p to our buffer, applying the policy set
+// by SetUTF8Policy().
+//
+// Each write is complete in itself: if p ends part-way through a UTF-8
+// character, that partial character is invalid.
+func (d *TextBuffer) Write(p []byte) (int, error) {
+	if d.utf8Policy == UTF8PassThrough {
+		return d.Buffer.Write(p)
+	}
+
+	return d.textEncoder().write(p, true)
+}
+
+// WriteString appends the given string to our buffer, applying the policy
+// set by SetUTF8Policy().
+func (d *TextBuffer) WriteString(s string) (int, error) {
+	if d.utf8Policy == UTF8PassThrough {
+		return d.Buffer.WriteString(s)
+	}
+
+	return d.textEncoder().write([]byte(s), true)
+}
+
+// WriteRune appends a single rune (a unicode character) to our buffer.
+//
+// Invalid runes are written as the unicode replacement character, U+FFFD,
+// unless you have set UTF8Error, when an EncodingError is returned.
+func (d *TextBuffer) WriteRune(r rune) (int, error) {
+	if d.utf8Policy == UTF8PassThrough {
+		return d.Buffer.WriteRune(r)
+	}
+
+	if err := d.textEncoder().checkRune(r); err != nil {
+		return 0, err
+	}
+
+	return d.WriteString(string(r))
+}
+
+// ReadFrom appends everything from r to our buffer, applying the policy
+// set by SetUTF8Policy().
+func (d *TextBuffer) ReadFrom(r io.Reader) (int64, error) {
+	if d.utf8Policy == UTF8PassThrough {
+		return d.Buffer.ReadFrom(r)
+	}
+
+	// r can split a UTF-8 character across two reads, so we only
+	// treat a partial character as invalid once r is exhausted
+	n, err := io.Copy(d.textEncoder(), r)
+	if err == nil {
+		err = d.textEncoder().Flush()
+	}
+
+	return n, err
+}
+
+// Printf writes formatted text to our buffer, just like
+// fmt.Fprintf() does. It returns the number of bytes written, and any
+// error encountered that caused the write to fail.
+func (d *TextBuffer) Printf(format string, a ...interface{}) (int, error) {
+	return fmt.Fprintf(d, format, a...)
+}
+
+// Println writes its operands to our buffer, separated by
+// spaces and followed by the line terminator set by SetLineTerminator().
+// It returns the number of bytes written, and any error encountered that
+// caused the write to fail.
+func (d *TextBuffer) Println(a ...interface{}) (int, error) {
+	line := fmt.Sprintln(a...)
+	return d.WriteLine(line[:len(line)-1])
+}
+
+// WriteLine writes the given line to our buffer, followed by
+// the line terminator set by SetLineTerminator(). It returns the number of
+// bytes written, and any error encountered that caused the write to fail.
+func (d *TextBuffer) WriteLine(line string) (int, error) {
+	return d.WriteString(line + lineTerminatorOrDefault(d.lineTerminator))
+}
+
+// WriteLines writes each of the given lines to our buffer, each
+// followed by the line terminator set by SetLineTerminator().
+//
+// It returns the total number of bytes written, and stops at the first
+// error encountered.
+func (d *TextBuffer) WriteLines(lines []string) (int, error) {
+	return d.WriteLinesSeq(slices.Values(lines))
+}
+
+// WriteLinesFrom writes each line received from the given channel to
+// our buffer, each followed by the line terminator set by
+// SetLineTerminator(), until the channel is closed.
+//
+// It returns the total number of bytes written, and the first error
+// encountered. See WriteLinesFrom() for what happens after an error.
+func (d *TextBuffer) WriteLinesFrom(lines <-chan string) (int, error) {
+	return d.WriteLinesSeq(drainingSeq(lines))
+}
+
+// WriteLinesSeq writes each line from the given iterator to our buffer,
+// each followed by the line terminator set by SetLineTerminator().
+//
+// It returns the total number of bytes written, and stops at the first
+// error encountered.
+func (d *TextBuffer) WriteLinesSeq(lines iter.Seq[string]) (int, error) {
+	return writeLines(d, lines, lineTerminatorOrDefault(d.lineTerminator))
+}
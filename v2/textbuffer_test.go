@@ -35,6 +35,10 @@
 package ioextra
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"iter"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,6 +49,47 @@ type interfaceCompatibility struct {
 	out TextWriter
 }
 
+// textWrapper is the API that TextBuffer, TextFile and TextIOWrapper
+// all share, for the tests that run against all three of them
+type textWrapper interface {
+	TextReaderWriter
+	TextPeeker
+	ParagraphsReader
+	LinesIterator
+	WordsIterator
+	Ints() iter.Seq2[int, error]
+	ReadLinesContext(ctx context.Context) (<-chan string, func() error)
+	ReadDelimited(sep string) <-chan string
+	ReadRecords(split bufio.SplitFunc) <-chan string
+	ReadRecordsContext(ctx context.Context, split bufio.SplitFunc) (<-chan string, func() error)
+	ReadParagraphsContext(ctx context.Context, opts ParagraphOptions) (<-chan []string, func() error)
+	ReadParagraphsJoined(opts ParagraphOptions) <-chan string
+	SetLineEnding(ending LineEnding)
+	SetScanOptions(opts ScanOptions)
+	SetUTF8Policy(policy UTF8Policy)
+}
+
+// textWrappers is a helper. It returns a function for each of our text
+// wrappers, that creates a wrapper holding the given data.
+//
+// The data is added as-is, so that you can still change the wrapper's
+// settings before you read from it.
+func textWrappers() map[string]func(data string) textWrapper {
+	return map[string]func(data string) textWrapper{
+		"TextBuffer": func(data string) textWrapper {
+			retval := NewTextBuffer()
+			retval.Buffer.WriteString(data)
+			return retval
+		},
+		"TextFile": func(data string) textWrapper {
+			return NewTextFile(createTestFile(data))
+		},
+		"TextIOWrapper": func(data string) textWrapper {
+			return NewTextIOWrapper(NopReadWriteCloser(bytes.NewBufferString(data)))
+		},
+	}
+}
+
 // ================================================================
 //
 // Constructors
@@ -153,6 +198,46 @@ func TestTextBufferImplementsTextReaderWriter(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestTextBufferImplementsLinesIterator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	var i interface{} = unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(LinesIterator)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
+func TestTextBufferImplementsWordsIterator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	var i interface{} = unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(WordsIterator)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
 // ================================================================
 //
 // TextReader interface
@@ -183,160 +268,295 @@ func TestTextBufferParseIntReturnsValueOnSuccess(t *testing.T) {
 	assert.Equal(t, expectedOutput, actualOutput)
 }
 
-func TestTextBufferReadLinesIteratesOverBuffer(t *testing.T) {
+func TestTextBufferParseInt64ReturnsValueOnSuccess(t *testing.T) {
 	t.Parallel()
 
 	// ----------------------------------------------------------------
 	// setup your test
 
-	unit := NewTextBuffer()
-	unit.WriteString("hello world\nhave a nice day")
+	testData := " 9000000000 \n"
+	expectedOutput := int64(9000000000)
 
-	expectedResult := []string{"hello world", "have a nice day"}
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
 
 	// ----------------------------------------------------------------
 	// perform the change
 
-	var actualResult []string
-	for line := range unit.ReadLines() {
-		actualResult = append(actualResult, line)
-	}
+	actualOutput, err := dest.ParseInt64()
 
 	// ----------------------------------------------------------------
 	// test the results
 
-	assert.Equal(t, expectedResult, actualResult)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
 }
 
-func TestTextBufferReadLinesEmptiesTheBuffer(t *testing.T) {
+func TestTextBufferParseIntBaseSupportsHexPrefix(t *testing.T) {
 	t.Parallel()
 
 	// ----------------------------------------------------------------
 	// setup your test
 
-	unit := NewTextBuffer()
-	unit.WriteString("hello world\nhave a nice day")
+	testData := "0xff_ff\n"
+	expectedOutput := int64(0xffff)
 
-	expectedResult := []string{"hello world", "have a nice day"}
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
 
 	// ----------------------------------------------------------------
 	// perform the change
 
-	var actualResult []string
-	for line := range unit.ReadLines() {
-		actualResult = append(actualResult, line)
-	}
+	actualOutput, err := dest.ParseIntBase(0)
 
-	extraOutput := []string{}
-	for line := range unit.ReadLines() {
-		extraOutput = append(extraOutput, line)
-	}
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestTextBufferParseIntBaseSupportsBinaryPrefix(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "-0b101\n"
+	expectedOutput := int64(-5)
+
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput, err := dest.ParseIntBase(0)
 
 	// ----------------------------------------------------------------
 	// test the results
 
-	assert.Equal(t, expectedResult, actualResult)
-	assert.Empty(t, extraOutput)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
 }
 
-func TestTextBufferReadWordsIteratesOverBuffer(t *testing.T) {
+func TestTextBufferParseIntBaseUsesTheGivenBase(t *testing.T) {
 	t.Parallel()
 
 	// ----------------------------------------------------------------
 	// setup your test
 
-	unit := NewTextBuffer()
-	unit.WriteString("hello world\nhave a nice day")
+	testData := "ff\n"
+	expectedOutput := int64(255)
 
-	expectedResult := []string{"hello", "world", "have", "a", "nice", "day"}
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
 
 	// ----------------------------------------------------------------
 	// perform the change
 
-	var actualResult []string
-	for word := range unit.ReadWords() {
-		actualResult = append(actualResult, word)
-	}
+	actualOutput, err := dest.ParseIntBase(16)
 
 	// ----------------------------------------------------------------
 	// test the results
 
-	assert.Equal(t, expectedResult, actualResult)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
 }
 
-func TestTextBufferStringReturnsBuffer(t *testing.T) {
+func TestTextBufferParseUintReturnsValueOnSuccess(t *testing.T) {
 	t.Parallel()
 
 	// ----------------------------------------------------------------
 	// setup your test
 
-	testData := "hello world\n"
+	testData := "010\n"
+	expectedOutput := uint64(10)
 
-	unit := NewTextBuffer()
-	unit.WriteString(testData)
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
 
-	expectedOutput := testData
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput, err := dest.ParseUint()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestTextBufferParseUintReturnsErrorForNegativeNumbers(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "-1\n"
+	expectedOutput := uint64(0)
+
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
 
 	// ----------------------------------------------------------------
 	// perform the change
 
-	actualOutput := unit.String()
+	actualOutput, err := dest.ParseUint()
 
 	// ----------------------------------------------------------------
 	// test the results
 
+	assert.NotNil(t, err)
 	assert.Equal(t, expectedOutput, actualOutput)
 }
 
-func TestTextBufferStringEmptiesTheBuffer(t *testing.T) {
+func TestTextBufferParseUintRejectsPrefixes(t *testing.T) {
 	t.Parallel()
 
 	// ----------------------------------------------------------------
 	// setup your test
 
-	testData := "hello world\n"
+	testData := "0x10\n"
+	expectedOutput := uint64(0)
 
-	unit := NewTextBuffer()
-	unit.WriteString(testData)
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
 
-	expectedOutput := testData
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput, err := dest.ParseUint()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestTextBufferParseUintBaseSupportsOctalPrefix(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "0o755\n"
+	expectedOutput := uint64(0755)
+
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
 
 	// ----------------------------------------------------------------
 	// perform the change
 
-	actualOutput := unit.String()
-	secondOutput := unit.String()
+	actualOutput, err := dest.ParseUintBase(0)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestTextBufferParseUintBaseUsesTheGivenBase(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "ff\n"
+	expectedOutput := uint64(255)
+
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput, err := dest.ParseUintBase(16)
 
 	// ----------------------------------------------------------------
 	// test the results
 
+	assert.Nil(t, err)
 	assert.Equal(t, expectedOutput, actualOutput)
-	assert.Empty(t, secondOutput)
 }
 
-func TestTextBufferStringsReturnsBuffer(t *testing.T) {
+func TestTextBufferParseFloatReturnsValueOnSuccess(t *testing.T) {
 	t.Parallel()
 
 	// ----------------------------------------------------------------
 	// setup your test
 
-	unit := NewTextBuffer()
-	unit.WriteString("hello world\nhave a nice day\n")
+	testData := " 3.25 \n"
+	expectedOutput := 3.25
 
-	expectedOutput := []string{"hello world", "have a nice day"}
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
 
 	// ----------------------------------------------------------------
 	// perform the change
 
-	actualOutput := unit.Strings()
+	actualOutput, err := dest.ParseFloat()
 
 	// ----------------------------------------------------------------
 	// test the results
 
+	assert.Nil(t, err)
 	assert.Equal(t, expectedOutput, actualOutput)
 }
 
-func TestTextBufferStringsEmptiesTheBufferBuffer(t *testing.T) {
+func TestTextBufferParseBoolReturnsValueOnSuccess(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "true\n"
+	expectedOutput := true
+
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput, err := dest.ParseBool()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestTextBufferParseBoolReturnsErrorOnParseFailure(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "yes\n"
+	expectedOutput := false
+
+	dest := NewTextBuffer()
+	dest.WriteString(testData)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput, err := dest.ParseBool()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestTextBufferReadLineDoesNotLoseBufferedData(t *testing.T) {
 	t.Parallel()
 
 	// ----------------------------------------------------------------
@@ -345,39 +565,572 @@ func TestTextBufferStringsEmptiesTheBufferBuffer(t *testing.T) {
 	unit := NewTextBuffer()
 	unit.WriteString("hello world\nhave a nice day\n")
 
-	expectedOutput := []string{"hello world", "have a nice day"}
+	expectedFirst := "hello world\n"
+	expectedRemainder := "have a nice day\n"
 
 	// ----------------------------------------------------------------
 	// perform the change
 
-	actualOutput := unit.Strings()
-	secondOutput := unit.String()
+	actualFirst, err := unit.ReadLine()
+	actualRemainder := unit.String()
 
 	// ----------------------------------------------------------------
 	// test the results
 
-	assert.Equal(t, expectedOutput, actualOutput)
-	assert.Empty(t, secondOutput)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedFirst, actualFirst)
+	assert.Equal(t, expectedRemainder, actualRemainder)
 }
 
-func TestTextBufferTrimmedStringReturnsBufferWithWhitespaceRemoved(t *testing.T) {
+func TestTextBufferReadFloatsIteratesOverBuffer(t *testing.T) {
 	t.Parallel()
 
 	// ----------------------------------------------------------------
 	// setup your test
 
 	unit := NewTextBuffer()
-	unit.WriteString(" hello world\n")
+	unit.WriteString("0.25\n0.5 abc\n")
 
-	expectedOutput := "hello world"
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []FloatResult
+	for result := range unit.ReadFloats() {
+		actualResult = append(actualResult, result)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Len(t, actualResult, 3)
+	assert.Equal(t, 0.25, actualResult[0].Value)
+	assert.Equal(t, 0.5, actualResult[1].Value)
+	assert.EqualError(t, actualResult[2].Err, `token 2 ("abc"): strconv.ParseFloat: parsing "abc": invalid syntax`)
+}
+
+func TestTextBufferSetLineEndingAppliesToAllLineReaders(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\r\nhave a nice day\rgoodbye\n")
+	unit.SetLineEnding(AcceptCR)
+
+	expectedLine := "hello world"
+	expectedStrings := []string{"have a nice day", "goodbye"}
 
 	// ----------------------------------------------------------------
 	// perform the change
 
-	actualOutput := unit.TrimmedString()
+	actualLine, err := unit.ReadLine()
+	actualStrings := unit.Strings()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedLine, actualLine)
+	assert.Equal(t, expectedStrings, actualStrings)
+}
+
+func TestTextBufferReadLinesIteratesOverBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\nhave a nice day")
+
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for line := range unit.ReadLines() {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestTextBufferReadLinesEmptiesTheBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\nhave a nice day")
+
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for line := range unit.ReadLines() {
+		actualResult = append(actualResult, line)
+	}
+
+	extraOutput := []string{}
+	for line := range unit.ReadLines() {
+		extraOutput = append(extraOutput, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Empty(t, extraOutput)
+}
+
+func TestTextBufferReadLinesContextIteratesOverBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\nhave a nice day")
+
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	linesChn, linesErr := unit.ReadLinesContext(context.Background())
+
+	var actualResult []string
+	for line := range linesChn {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Nil(t, linesErr())
+}
+
+func TestTextBufferSetScanOptionsAppliesToReadLines(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\nhave a nice day")
+	unit.SetScanOptions(ScanOptions{MaxTokenSize: 8, TooLong: TooLongTruncate})
+
+	expectedResult := []string{"hello wo", "have a n"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for line := range unit.ReadLines() {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestTextBufferLinesIteratesOverBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\nhave a nice day")
+
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for line := range unit.Lines() {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestTextBufferReadWordsIteratesOverBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\nhave a nice day")
+
+	expectedResult := []string{"hello", "world", "have", "a", "nice", "day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for word := range unit.ReadWords() {
+		actualResult = append(actualResult, word)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestTextBufferStringReturnsBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "hello world\n"
+
+	unit := NewTextBuffer()
+	unit.WriteString(testData)
+
+	expectedOutput := testData
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput := unit.String()
 
 	// ----------------------------------------------------------------
 	// test the results
 
 	assert.Equal(t, expectedOutput, actualOutput)
 }
+
+func TestTextBufferStringEmptiesTheBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "hello world\n"
+
+	unit := NewTextBuffer()
+	unit.WriteString(testData)
+
+	expectedOutput := testData
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput := unit.String()
+	secondOutput := unit.String()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedOutput, actualOutput)
+	assert.Empty(t, secondOutput)
+}
+
+func TestTextBufferStringsReturnsBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\nhave a nice day\n")
+
+	expectedOutput := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput := unit.Strings()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestTextBufferStringsEmptiesTheBufferBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("hello world\nhave a nice day\n")
+
+	expectedOutput := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput := unit.Strings()
+	secondOutput := unit.String()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedOutput, actualOutput)
+	assert.Empty(t, secondOutput)
+}
+
+func TestTextBufferReadAllStringReturnsBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "hello world\n"
+
+	unit := NewTextBuffer()
+	unit.WriteString(testData)
+
+	expectedOutput := testData
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput, err := unit.ReadAllString()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+func TestTextBufferTrimmedStringReturnsBufferWithWhitespaceRemoved(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString(" hello world\n")
+
+	expectedOutput := "hello world"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualOutput := unit.TrimmedString()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedOutput, actualOutput)
+}
+
+// ================================================================
+//
+// TextWriter interface
+//
+// ----------------------------------------------------------------
+
+func TestTextBufferWriteLineAddsTheLineTerminator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+
+	expectedResult := "hello world\n"
+	expectedLen := len(expectedResult)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.WriteLine("hello world")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedLen, actualLen)
+	assert.Equal(t, expectedResult, unit.String())
+}
+
+func TestTextBufferWriteLineUsesTheConfiguredLineTerminator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.SetLineTerminator("\r\n")
+
+	expectedResult := "hello world\r\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.WriteLine("hello world")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, unit.String())
+}
+
+func TestTextBufferImplementsLineWriter(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	var i interface{} = unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(LineWriter)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
+func TestTextBufferWriteLinesWritesEachLine(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.SetLineTerminator("\r\n")
+
+	expectedResult := "hello world\r\nhave a nice day\r\n"
+	expectedLen := len(expectedResult)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.WriteLines([]string{"hello world", "have a nice day"})
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedLen, actualLen)
+	assert.Equal(t, expectedResult, unit.String())
+}
+
+func TestTextBufferWriteLinesFromWritesEachLineFromTheChannel(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	src := NewTextBuffer()
+	src.WriteString("hello world\nhave a nice day")
+
+	unit := NewTextBuffer()
+
+	expectedResult := "hello world\nhave a nice day\n"
+	expectedLen := len(expectedResult)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.WriteLinesFrom(src.ReadLines())
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedLen, actualLen)
+	assert.Equal(t, expectedResult, unit.String())
+}
+
+func TestTextBufferWriteLinesSeqWritesEachLineFromTheIterator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	src := NewTextBuffer()
+	src.WriteString("hello world\nhave a nice day")
+
+	unit := NewTextBuffer()
+
+	expectedResult := "hello world\nhave a nice day\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.WriteLinesSeq(src.Lines())
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, unit.String())
+}
+
+func TestTextBufferPrintfWritesFormattedText(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+
+	expectedResult := "width: 640"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.Printf("width: %d", 640)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(expectedResult), actualLen)
+	assert.Equal(t, expectedResult, unit.String())
+}
+
+func TestTextBufferPrintlnUsesTheConfiguredLineTerminator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.SetLineTerminator("\r\n")
+
+	expectedResult := "width: 640\r\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.Println("width:", 640)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(expectedResult), actualLen)
+	assert.Equal(t, expectedResult, unit.String())
+}
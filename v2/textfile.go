@@ -35,12 +35,54 @@
 package ioextra
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
 	"os"
+	"slices"
+	"strconv"
+	"syscall"
 )
 
 // TextFile is an os.File with TextReader and TextWriter compatibility.
 type TextFile struct {
-	os.File
+	*os.File
+
+	// reader buffers everything that we read from the underlying file,
+	// so that no data is lost between calls
+	reader *bufio.Reader
+
+	// scanOptions controls the bufio.Scanner used by ReadLines() and
+	// ReadWords()
+	scanOptions ScanOptions
+
+	// lineEnding controls what our read methods do with the end of
+	// each line
+	lineEnding LineEnding
+
+	// lineTerminator is what WriteLine() adds to the end of each line
+	lineTerminator string
+
+	// encoding is the character encoding of the underlying file
+	encoding Encoding
+
+	// strictEncoding tells us to report invalid data, instead of
+	// replacing it
+	strictEncoding bool
+
+	// utf8Policy tells us what to do with invalid UTF-8
+	utf8Policy UTF8Policy
+
+	// decoder converts the underlying file into UTF-8, when we are not
+	// using EncodingDefault and UTF8PassThrough
+	decoder *decodingReader
+
+	// encoder converts what we write into our encoding, when we are
+	// not using EncodingDefault and UTF8PassThrough
+	encoder *encodingWriter
 }
 
 // ===========================================================================
@@ -51,19 +93,153 @@ type TextFile struct {
 
 // NewTextFile creates a new output destination that reads from / writes to
 // and underlying file.
+//
+// The TextFile shares the given *os.File. Closing either of them closes
+// the file for both.
 func NewTextFile(f *os.File) *TextFile {
-	retval := TextFile{*f}
+	retval := TextFile{File: f}
 
 	// all done
 	return &retval
 }
 
+// OpenTextFile opens the named file for reading, and wraps it in a
+// TextFile.
+func OpenTextFile(name string) (*TextFile, error) {
+	return OpenTextFileWith(name, os.O_RDONLY, 0)
+}
+
+// CreateTextFile creates (or truncates) the named file for reading and
+// writing, and wraps it in a TextFile.
+func CreateTextFile(name string) (*TextFile, error) {
+	return OpenTextFileWith(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+// OpenTextFileWith opens the named file with the given flags (eg,
+// os.O_APPEND) and permissions, just like os.OpenFile() does, and wraps
+// it in a TextFile.
+func OpenTextFileWith(name string, flag int, perm os.FileMode) (*TextFile, error) {
+	f, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTextFile(f), nil
+}
+
 // ================================================================
 //
 // Helpers
 //
 // ----------------------------------------------------------------
 
+// bufferedReader returns the buffered reader that sits between us and
+// the underlying file, creating it if necessary.
+//
+// All of our read methods must go through this, otherwise we will skip
+// over any data that it has already buffered.
+func (d *TextFile) bufferedReader() *bufio.Reader {
+	if d.reader == nil {
+		d.reader = bufio.NewReader(d.textSource())
+	}
+
+	return d.reader
+}
+
+// isPlainUTF8 returns true if we pass data through untouched, without
+// any decoding, encoding or checking.
+func (d *TextFile) isPlainUTF8() bool {
+	return d.encoding == EncodingDefault && d.utf8Policy == UTF8PassThrough
+}
+
+// textSource returns where our buffered reader gets its data from: the
+// underlying file, or a decoder that sits in front of it.
+func (d *TextFile) textSource() io.Reader {
+	if d.isPlainUTF8() {
+		return d.File
+	}
+
+	if d.decoder == nil {
+		d.decoder = newDecodingReader(d.File, d.encoding, d.strictEncoding, d.utf8Policy)
+	}
+
+	return d.decoder
+}
+
+// textSink returns where our write methods send their data: the
+// underlying file, or an encoder that sits in front of it.
+func (d *TextFile) textSink() io.Writer {
+	if d.isPlainUTF8() {
+		return d.File
+	}
+
+	if d.encoder == nil {
+		encoding := d.encoding
+		if encoding == EncodingAuto {
+			encoding = EncodingUTF8
+		}
+		d.encoder = newEncodingWriter(d.File, encoding, d.strictEncoding, d.utf8Policy)
+	}
+
+	return d.encoder
+}
+
+// unreadLen returns how many bytes we have read from the underlying
+// file, but not yet returned to our caller.
+func (d *TextFile) unreadLen() int {
+	if d.reader == nil {
+		return 0
+	}
+
+	buffered := d.reader.Buffered()
+	if d.decoder == nil {
+		return buffered
+	}
+
+	// our buffered reader holds decoded text, which may be a different
+	// size to the original data in the file
+	text, _ := d.reader.Peek(buffered)
+	return d.decoder.unreadLen(text)
+}
+
+// resetReader throws away any buffered data, after the underlying file
+// has been moved to the given position.
+func (d *TextFile) resetReader(pos int64) {
+	if d.decoder != nil {
+		d.decoder.reset(pos == 0, pos)
+	}
+	if d.reader != nil {
+		d.reader.Reset(d.textSource())
+	}
+}
+
+// discardReadBuffer moves the underlying file's position back to where
+// our caller thinks it is, and throws away any buffered data.
+//
+// We need to do this before writing to the file, otherwise the write
+// would land after the data that we have read ahead.
+//
+// Pipes, ttys and sockets cannot seek, and their reads and writes are
+// separate streams anyway. For those, we keep the buffered data, and
+// the write goes wherever the stream is.
+func (d *TextFile) discardReadBuffer() error {
+	unread := d.unreadLen()
+	if unread == 0 {
+		return nil
+	}
+
+	pos, err := d.File.Seek(int64(-unread), io.SeekCurrent)
+	if errors.Is(err, syscall.ESPIPE) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	d.resetReader(pos)
+
+	return nil
+}
+
 // Rewind moves the read/write position to the start of the underlying file.
 func (d *TextFile) Rewind() error {
 	_, err := d.Seek(0, 0)
@@ -84,12 +260,204 @@ func (d *TextFile) MustRewind() error {
 	return err
 }
 
+// ===========================================================================
+//
+// Settings
+//
+// ---------------------------------------------------------------------------
+
+// SetScanOptions sets the buffer sizes, and the policy for tokens that
+// are too long, used by ReadLines(), ReadWords() and Strings().
+func (d *TextFile) SetScanOptions(opts ScanOptions) {
+	d.scanOptions = opts
+}
+
+// SetLineEnding sets what ReadLine(), ReadLines(), Lines() and Strings()
+// do with the end of each line.
+func (d *TextFile) SetLineEnding(ending LineEnding) {
+	d.lineEnding = ending
+}
+
+// SetLineTerminator sets what WriteLine() adds to the end of each line.
+// Use an empty string to go back to DefaultLineTerminator.
+func (d *TextFile) SetLineTerminator(terminator string) {
+	d.lineTerminator = terminator
+}
+
+// SetEncoding sets the character encoding of the underlying file. Our
+// read methods decode it into UTF-8, and our write methods encode UTF-8
+// back into it.
+//
+// Call it before you read or write anything. Any data that has already
+// been buffered is thrown away.
+//
+// Seek() positions are always in bytes of the underlying file.
+func (d *TextFile) SetEncoding(encoding Encoding) {
+	d.encoding = encoding
+	d.resetEncoding()
+}
+
+// SetStrictEncoding sets whether or not our read and write methods
+// return an EncodingError when they find data that is invalid in our
+// encoding. When it is not set, invalid data is replaced with the
+// unicode replacement character (or '?', if the encoding does not
+// support that).
+//
+// Call it before you read or write anything. Any data that has already
+// been buffered is thrown away.
+func (d *TextFile) SetStrictEncoding(strict bool) {
+	d.strictEncoding = strict
+	d.resetEncoding()
+}
+
+// SetUTF8Policy sets what our read and write methods do when they find
+// invalid UTF-8: pass it through untouched (the default), replace it, or
+// return an EncodingError.
+//
+// Call it before you read or write anything. Any data that has already
+// been buffered is thrown away.
+func (d *TextFile) SetUTF8Policy(policy UTF8Policy) {
+	d.utf8Policy = policy
+	d.resetEncoding()
+}
+
+// flushEncoder writes out anything that our encoder is holding on to
+// (an incomplete UTF-8 character, from the end of the last write).
+func (d *TextFile) flushEncoder() error {
+	if d.encoder == nil || len(d.encoder.pending) == 0 {
+		return nil
+	}
+
+	err := d.discardReadBuffer()
+	if err != nil {
+		return err
+	}
+
+	return d.encoder.Flush()
+}
+
+// resetEncoding makes sure that our next read or write uses our
+// current encoding settings
+func (d *TextFile) resetEncoding() {
+	d.reader = nil
+	d.decoder = nil
+	d.encoder = nil
+}
+
+// ===========================================================================
+//
+// io.Reader interface
+//
+// ---------------------------------------------------------------------------
+
+// Read reads up to len(p) bytes from our underlying file. Any data that
+// has already been buffered by our other read methods is returned first.
+func (d *TextFile) Read(p []byte) (int, error) {
+	return d.bufferedReader().Read(p)
+}
+
+// WriteTo writes the remaining data in our underlying file to w. It is
+// here to stop io.Copy() from bypassing our buffered data.
+func (d *TextFile) WriteTo(w io.Writer) (int64, error) {
+	return d.bufferedReader().WriteTo(w)
+}
+
+// ===========================================================================
+//
+// io.Seeker interface
+//
+// ---------------------------------------------------------------------------
+
+// Seek sets the read/write position of the underlying file, and discards
+// any data that we have buffered.
+//
+// Offsets relative to io.SeekCurrent are relative to the data that has
+// been returned to the caller, not to the data that we have buffered.
+func (d *TextFile) Seek(offset int64, whence int) (int64, error) {
+	if whence == io.SeekCurrent {
+		offset -= int64(d.unreadLen())
+	}
+
+	retval, err := d.File.Seek(offset, whence)
+	if err != nil {
+		return retval, err
+	}
+	d.resetReader(retval)
+
+	return retval, nil
+}
+
+// ===========================================================================
+//
+// io.Closer interface
+//
+// ---------------------------------------------------------------------------
+
+// Close closes the underlying file, and discards any data that we have
+// buffered but not yet returned.
+//
+// If the last write ended part-way through a UTF-8 character, that
+// partial character is written out first, according to the policies set
+// by SetStrictEncoding() and SetUTF8Policy(). Close returns the
+// EncodingError if those policies reject it.
+func (d *TextFile) Close() error {
+	err := d.flushEncoder()
+	d.resetEncoding()
+	closeErr := d.File.Close()
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
 // ===========================================================================
 //
 // TextReader interface
 //
 // ---------------------------------------------------------------------------
 
+// Floats returns an iterator that you can `range` over to get each
+// remaining whitespace-separated number from our underlying file, as a
+// float64. Tokens that are not numbers are returned with a *TokenError.
+func (d *TextFile) Floats() iter.Seq2[float64, error] {
+	return parseTokens(d.WordsWithErrors(), parseFloat64)
+}
+
+// Ints returns an iterator that you can `range` over to get each
+// remaining whitespace-separated number from our underlying file, as an
+// int. Tokens that are not integers are returned with a *TokenError.
+func (d *TextFile) Ints() iter.Seq2[int, error] {
+	return parseTokens(d.WordsWithErrors(), strconv.Atoi)
+}
+
+// Lines returns an iterator that you can `range` over to get each
+// remaining line from our underlying file. It stops at the first read
+// error.
+func (d *TextFile) Lines() iter.Seq[string] {
+	return withoutErrors(d.LinesWithErrors())
+}
+
+// LinesWithErrors returns an iterator that you can `range` over to get
+// each remaining line from our underlying file, and any read error.
+func (d *TextFile) LinesWithErrors() iter.Seq2[string, error] {
+	return newReadIterator(func() (string, error) {
+		return ReadLineWithEnding(d.bufferedReader(), d.lineEnding.iteratorEnding())
+	})
+}
+
+// ParseBool returns the next line in our underlying file as a bool.
+//
+// See ParseBool() for the values that it accepts.
+func (d *TextFile) ParseBool() (bool, error) {
+	return ParseBool(d)
+}
+
+// ParseFloat returns the next line in our underlying file as a float64.
+func (d *TextFile) ParseFloat() (float64, error) {
+	return ParseFloat(d)
+}
+
 // ParseInt returns the remaining data in our underlying file as an integer.
 //
 // If the file contains anything other than a valid number, an error
@@ -98,22 +466,204 @@ func (d *TextFile) ParseInt() (int, error) {
 	return ParseInt(d)
 }
 
+// ParseInt64 returns the next line in our underlying file as a base 10
+// int64.
+func (d *TextFile) ParseInt64() (int64, error) {
+	return ParseInt64(d)
+}
+
+// ParseIntBase returns the next line in our underlying file as an int64
+// in the given base.
+//
+// Use base 0 to support 0x, 0o and 0b prefixes, and underscores between
+// digits.
+func (d *TextFile) ParseIntBase(base int) (int64, error) {
+	return ParseIntBase(d, base)
+}
+
+// ParseUint returns the next line in our underlying file as a base 10
+// uint64.
+func (d *TextFile) ParseUint() (uint64, error) {
+	return ParseUint(d)
+}
+
+// ParseUintBase returns the next line in our underlying file as a
+// uint64 in the given base.
+//
+// Use base 0 to support 0x, 0o and 0b prefixes, and underscores between
+// digits.
+func (d *TextFile) ParseUintBase(base int) (uint64, error) {
+	return ParseUintBase(d, base)
+}
+
+// ReadAllString returns all of the remaining data in our underlying
+// file as a single string. Unlike String(), it returns any read error
+// instead of panicking.
+func (d *TextFile) ReadAllString() (string, error) {
+	return ReadAllString(d)
+}
+
+// ReadAllStrings returns all of the remaining data in our underlying
+// file as an array of strings, one line per array entry. Unlike
+// Strings(), it also returns any read error.
+func (d *TextFile) ReadAllStrings() ([]string, error) {
+	return collectStrings(d.ReadLinesWithOptions(context.Background(), d.scanOptions))
+}
+
+// ReadAllTrimmed returns all of the remaining data in our underlying
+// file as a string, with any leading or trailing whitespace removed.
+// Unlike TrimmedString(), it returns any read error instead of
+// panicking.
+func (d *TextFile) ReadAllTrimmed() (string, error) {
+	return ReadAllTrimmed(d)
+}
+
+// ReadDelimited returns a channel that you can `range` over to get each
+// remaining `sep`-separated record from our underlying file. The
+// separator is not included in the records.
+func (d *TextFile) ReadDelimited(sep string) <-chan string {
+	return d.ReadRecords(NewDelimiterSplitFunc(sep))
+}
+
+// ReadFloats returns a channel that you can `range` over to get each
+// remaining whitespace-separated number from our underlying file, as a
+// float64. Tokens that are not numbers are sent with a *TokenError.
+func (d *TextFile) ReadFloats() <-chan FloatResult {
+	chn, _ := d.ReadFloatsContext(context.Background())
+	return chn
+}
+
+// ReadFloatsContext works just like ReadFloats(), but stops when the given
+// context is cancelled. See ReadFloatsContext() for details.
+func (d *TextFile) ReadFloatsContext(ctx context.Context) (<-chan FloatResult, func() error) {
+	return floatResults(ctx, d.Floats())
+}
+
+// ReadInts returns a channel that you can `range` over to get each
+// remaining whitespace-separated number from our underlying file, as an
+// int. Tokens that are not integers are sent with a *TokenError.
+func (d *TextFile) ReadInts() <-chan IntResult {
+	chn, _ := d.ReadIntsContext(context.Background())
+	return chn
+}
+
+// ReadIntsContext works just like ReadInts(), but stops when the given
+// context is cancelled. See ReadIntsContext() for details.
+func (d *TextFile) ReadIntsContext(ctx context.Context) (<-chan IntResult, func() error) {
+	return intResults(ctx, d.Ints())
+}
+
 // ReadLine returns the next line of data in our underlying file, or an
 // error if a problem was encountered.
 func (d *TextFile) ReadLine() (string, error) {
-	return ReadLine(d)
+	return ReadLineWithEnding(d.bufferedReader(), d.lineEnding)
 }
 
 // ReadLines returns a channel that you can `range` over to get each
 // remaining line from our underlying file.
 func (d *TextFile) ReadLines() <-chan string {
-	return ReadLines(d)
+	chn, _ := d.ReadLinesWithOptions(context.Background(), d.scanOptions)
+	return chn
+}
+
+// ReadLinesContext returns a channel that you can `range` over to get
+// each remaining line from our underlying file, until the given context
+// is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextFile) ReadLinesContext(ctx context.Context) (<-chan string, func() error) {
+	return d.ReadLinesWithOptions(ctx, d.scanOptions)
+}
+
+// ReadLinesWithOptions works just like ReadLinesContext(), but uses the
+// given ScanOptions instead of the ones set by SetScanOptions().
+func (d *TextFile) ReadLinesWithOptions(ctx context.Context, opts ScanOptions) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, d, d.lineEnding.SplitFunc(), opts)
+}
+
+// ReadParagraphs returns a channel that you can `range` over to get
+// each remaining paragraph from our underlying file. Paragraphs are
+// blocks of lines, separated by blank lines.
+//
+// Each paragraph is sent as an array of lines, without their line
+// endings. Use ParagraphOptions to decide which lines count as blank,
+// and whether or not they are kept.
+func (d *TextFile) ReadParagraphs(opts ParagraphOptions) <-chan []string {
+	chn, _ := d.ReadParagraphsContext(context.Background(), opts)
+	return chn
+}
+
+// ReadParagraphsContext works just like ReadParagraphs(), until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextFile) ReadParagraphsContext(ctx context.Context, opts ParagraphOptions) (<-chan []string, func() error) {
+	return ReadParagraphsWithOptions(ctx, d, opts, d.scanOptions)
+}
+
+// ReadParagraphsJoined works just like ReadParagraphs(), but sends each
+// paragraph as a single string. The lines of the paragraph are joined by
+// their original line endings.
+func (d *TextFile) ReadParagraphsJoined(opts ParagraphOptions) <-chan string {
+	return d.ReadRecords(NewParagraphSplitFunc(opts))
+}
+
+// ReadRecords returns a channel that you can `range` over to get each
+// remaining record from our underlying file, as found by the given
+// bufio.SplitFunc (eg, ScanNUL, ScanParagraphs or bufio.ScanRunes).
+func (d *TextFile) ReadRecords(split bufio.SplitFunc) <-chan string {
+	chn, _ := d.ReadRecordsContext(context.Background(), split)
+	return chn
+}
+
+// ReadRecordsContext works just like ReadRecords(), until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextFile) ReadRecordsContext(ctx context.Context, split bufio.SplitFunc) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, d, split, d.scanOptions)
 }
 
 // ReadWords returns a channel that you can `range` over to get each
 // remaining word from our underlying file.
 func (d *TextFile) ReadWords() <-chan string {
-	return ReadWords(d)
+	chn, _ := ReadWordsWithOptions(context.Background(), d, d.scanOptions)
+	return chn
+}
+
+// ReadWordsContext returns a channel that you can `range` over to get
+// each remaining word from our underlying file, until the given context
+// is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextFile) ReadWordsContext(ctx context.Context) (<-chan string, func() error) {
+	return ReadWordsWithOptions(ctx, d, d.scanOptions)
+}
+
+// ReadWordsWithOptions works just like ReadWordsContext(), but uses the
+// given ScanOptions instead of the ones set by SetScanOptions().
+func (d *TextFile) ReadWordsWithOptions(ctx context.Context, opts ScanOptions) (<-chan string, func() error) {
+	return ReadWordsWithOptions(ctx, d, opts)
+}
+
+// ScanLine reads the next line from our underlying file, and decodes its
+// whitespace-separated fields into the given destinations.
+//
+// See ScanLine() for the supported destinations.
+func (d *TextFile) ScanLine(dest ...interface{}) error {
+	return ScanLine(d, dest...)
+}
+
+// ScanLineDelimited reads the next line from our underlying file, and
+// decodes its sep-separated fields into the given destinations.
+//
+// See ScanLine() for the supported destinations.
+func (d *TextFile) ScanLineDelimited(sep string, dest ...interface{}) error {
+	return ScanLineDelimited(d, sep, dest...)
 }
 
 // String returns all of the remaining data in our underlying file as a
@@ -134,18 +684,160 @@ func (d *TextFile) TrimmedString() string {
 	return TrimmedString(d)
 }
 
+// Words returns an iterator that you can `range` over to get each
+// remaining word from our underlying file. It stops at the first read
+// error.
+func (d *TextFile) Words() iter.Seq[string] {
+	return withoutErrors(d.WordsWithErrors())
+}
+
+// WordsWithErrors returns an iterator that you can `range` over to get
+// each remaining word from our underlying file, and any read error.
+func (d *TextFile) WordsWithErrors() iter.Seq2[string, error] {
+	return newReadIterator(func() (string, error) {
+		return readWord(d.bufferedReader())
+	})
+}
+
 // ===========================================================================
 //
-// TextWriter interface
+// TextPeeker interface
+//
+// ---------------------------------------------------------------------------
+
+// PeekLine returns the next line from our underlying file, without
+// consuming it. It returns the same line that ReadLine() would.
 //
-// The majority of the TextWriter interface is already handled by the
-// underlying os.File.
+// PeekLine can only look as far ahead as our read buffer (4096 bytes).
+// If the line is longer than that, it returns as much of the line as it
+// can, and bufio.ErrBufferFull.
+func (d *TextFile) PeekLine() (string, error) {
+	return peekLineWithEnding(d.bufferedReader(), d.lineEnding)
+}
+
+// PeekRune returns the next character from our underlying file, and its
+// size in bytes, without consuming it.
+func (d *TextFile) PeekRune() (rune, int, error) {
+	return peekRune(d.bufferedReader())
+}
+
+// ReadRune returns the next character from our underlying file, and its
+// size in bytes.
+func (d *TextFile) ReadRune() (rune, int, error) {
+	return d.bufferedReader().ReadRune()
+}
+
+// UnreadRune puts the last character returned by ReadRune() back. It must
+// be called straight after ReadRune().
+func (d *TextFile) UnreadRune() error {
+	return d.bufferedReader().UnreadRune()
+}
+
+// ===========================================================================
+//
+// TextWriter interface
 //
 // ---------------------------------------------------------------------------
 
+// Write writes len(p) bytes to the underlying file, at the position
+// after the last data that we have returned to the caller. If the file
+// cannot seek (eg, it is a pipe), it writes at the file's current
+// position instead.
+func (d *TextFile) Write(p []byte) (int, error) {
+	err := d.discardReadBuffer()
+	if err != nil {
+		return 0, err
+	}
+
+	return d.textSink().Write(p)
+}
+
+// WriteString writes a string to the underlying file, at the position
+// after the last data that we have returned to the caller.
+func (d *TextFile) WriteString(s string) (int, error) {
+	return WriteString(d, s)
+}
+
+// ReadFrom copies everything from r into the underlying file, at the
+// position after the last data that we have returned to the caller.
+func (d *TextFile) ReadFrom(r io.Reader) (int64, error) {
+	err := d.discardReadBuffer()
+	if err != nil {
+		return 0, err
+	}
+
+	if !d.isPlainUTF8() {
+		return io.Copy(d.textSink(), r)
+	}
+
+	return d.File.ReadFrom(r)
+}
+
+// Printf writes formatted text to the underlying file, just like
+// fmt.Fprintf() does. It returns the number of bytes written, and any
+// error encountered that caused the write to fail.
+func (d *TextFile) Printf(format string, a ...interface{}) (int, error) {
+	return fmt.Fprintf(d, format, a...)
+}
+
+// Println writes its operands to the underlying file, separated by
+// spaces and followed by the line terminator set by SetLineTerminator().
+// It returns the number of bytes written, and any error encountered that
+// caused the write to fail.
+func (d *TextFile) Println(a ...interface{}) (int, error) {
+	line := fmt.Sprintln(a...)
+	return d.WriteLine(line[:len(line)-1])
+}
+
+// WriteLine writes the given line to the underlying file, followed by
+// the line terminator set by SetLineTerminator(). It returns the number of
+// bytes written, and any error encountered that caused the write to fail.
+func (d *TextFile) WriteLine(line string) (int, error) {
+	return d.WriteString(line + lineTerminatorOrDefault(d.lineTerminator))
+}
+
+// WriteLines writes each of the given lines to the underlying file, each
+// followed by the line terminator set by SetLineTerminator().
+//
+// It returns the total number of bytes written, and stops at the first
+// error encountered.
+func (d *TextFile) WriteLines(lines []string) (int, error) {
+	return d.WriteLinesSeq(slices.Values(lines))
+}
+
+// WriteLinesFrom writes each line received from the given channel to
+// the underlying file, each followed by the line terminator set by
+// SetLineTerminator(), until the channel is closed.
+//
+// It returns the total number of bytes written, and the first error
+// encountered. See WriteLinesFrom() for what happens after an error.
+func (d *TextFile) WriteLinesFrom(lines <-chan string) (int, error) {
+	return d.WriteLinesSeq(drainingSeq(lines))
+}
+
+// WriteLinesSeq writes each line from the given iterator to the
+// underlying file, each followed by the line terminator set by
+// SetLineTerminator().
+//
+// It returns the total number of bytes written, and stops at the first
+// error encountered.
+func (d *TextFile) WriteLinesSeq(lines iter.Seq[string]) (int, error) {
+	return writeLines(d, lines, lineTerminatorOrDefault(d.lineTerminator))
+}
+
 // WriteRune writes a single rune (a unicode character) to the underlying
 // file. It returns the number of types written, and any error encountered
 // that caused the write to file.
+//
+// Invalid runes are written as the unicode replacement character, U+FFFD,
+// unless you have set UTF8Error or strict encoding, when an EncodingError
+// is returned.
 func (d *TextFile) WriteRune(r rune) (int, error) {
+	if encoder, ok := d.textSink().(*encodingWriter); ok {
+		if err := encoder.checkRune(r); err != nil {
+			return 0, err
+		}
+	}
+
 	return WriteRune(d, r)
 }
@@ -38,6 +38,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,6 +90,125 @@ func TestNewTextFileCreatesATextFile(t *testing.T) {
 	assert.NotNil(t, dest)
 }
 
+func TestNewTextFileSharesTheGivenFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	f := createTestFile("hello world\n")
+	unit := NewTextFile(f)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	// the original *os.File must know that it has been closed, rather
+	// than having its file descriptor closed behind its back
+	_, err := f.Write([]byte("have a nice day\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.Same(t, f, unit.File)
+}
+
+func TestCreateTextFileCreatesAnEmptyFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "output.txt")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit, err := CreateTextFile(name)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	defer unit.Close()
+
+	unit.WriteLine("hello world")
+	unit.Rewind()
+	assert.Equal(t, "hello world\n", unit.String())
+}
+
+func TestOpenTextFileOpensAnExistingFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "input.txt")
+	os.WriteFile(name, []byte("hello world\nhave a nice day\n"), 0644)
+
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit, err := OpenTextFile(name)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	defer unit.Close()
+
+	assert.Equal(t, expectedResult, unit.Strings())
+}
+
+func TestOpenTextFileReturnsErrorForMissingFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "does-not-exist.txt")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit, err := OpenTextFile(name)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Nil(t, unit)
+}
+
+func TestOpenTextFileWithUsesTheGivenFlags(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "log.txt")
+	os.WriteFile(name, []byte("hello world\n"), 0644)
+
+	expectedResult := "hello world\nhave a nice day\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit, err := OpenTextFileWith(name, os.O_WRONLY|os.O_APPEND, 0)
+	assert.Nil(t, err)
+	unit.WriteLine("have a nice day")
+	unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	actualResult, _ := os.ReadFile(name)
+	assert.Equal(t, expectedResult, string(actualResult))
+}
+
 // ================================================================
 //
 // Interface compatibility
@@ -292,6 +412,123 @@ func TestTextFileParseIntReturnsErrorWhenFileIsClosed(t *testing.T) {
 	assert.Equal(t, expectedOutput, actualOutput)
 }
 
+func TestTextFileParseFunctionsReadOneLineEach(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "0x10\n-20\n2.5\nfalse\n"
+	unit := NewTextFile(
+		createTestFile(testData),
+	)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualHex, err1 := unit.ParseIntBase(0)
+	actualInt64, err2 := unit.ParseInt64()
+	actualFloat, err3 := unit.ParseFloat()
+	actualBool, err4 := unit.ParseBool()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Nil(t, err4)
+	assert.Equal(t, int64(16), actualHex)
+	assert.Equal(t, int64(-20), actualInt64)
+	assert.Equal(t, 2.5, actualFloat)
+	assert.False(t, actualBool)
+}
+
+func TestTextFileReadLineDoesNotLoseBufferedData(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "hello world\nhave a nice day\n"
+	unit := NewTextFile(
+		createTestFile(testData),
+	)
+
+	expectedFirst := "hello world\n"
+	expectedSecond := "have a nice day\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualFirst, err1 := unit.ReadLine()
+	actualSecond, err2 := unit.ReadLine()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, expectedFirst, actualFirst)
+	assert.Equal(t, expectedSecond, actualSecond)
+}
+
+func TestTextFileReadLineCanBeMixedWithOtherReadMethods(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "100\nhello world\nhave a nice day\n"
+	unit := NewTextFile(
+		createTestFile(testData),
+	)
+
+	expectedInt := 100
+	expectedLines := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualInt, err := unit.ParseInt()
+	actualLines := unit.Strings()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedInt, actualInt)
+	assert.Equal(t, expectedLines, actualLines)
+}
+
+func TestTextFileWriteAfterReadLineWritesAfterTheLine(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "hello world\nhave a nice day\n"
+	unit := NewTextFile(
+		createTestFile(testData),
+	)
+
+	expectedResult := "hello world\ngoodbye\nice day\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.ReadLine()
+	unit.WriteString("goodbye\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	unit.Rewind()
+	actualResult := unit.String()
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
 func TestTextFileReadLinesIteratesOverUnderlyingFile(t *testing.T) {
 	t.Parallel()
 
@@ -418,6 +655,34 @@ func TestTextFileReadWordsActsLikeAStream(t *testing.T) {
 	assert.Empty(t, secondResult)
 }
 
+func TestTextFileWordsIteratesOverTheUnderlyingFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "hello world\nhave a nice day"
+	unit := NewTextFile(
+		createTestFile(testData),
+	)
+
+	expectedResult := []string{"hello", "world", "have", "a", "nice", "day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for word, err := range unit.WordsWithErrors() {
+		assert.Nil(t, err)
+		actualResult = append(actualResult, word)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
 func TestTextFileStringReturnsTheUnderlyingFile(t *testing.T) {
 	t.Parallel()
 
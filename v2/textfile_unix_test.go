@@ -0,0 +1,100 @@
+//go:build unix
+
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSocketPairTextFiles is a helper. It returns two TextFiles that
+// talk to each other over a (non-seekable) UNIX socket pair.
+func newSocketPairTextFiles(t *testing.T) (*TextFile, *TextFile) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		t.Fatalf("socketpair failed: %v", err)
+	}
+
+	ours := NewTextFile(os.NewFile(uintptr(fds[0]), "ours"))
+	theirs := NewTextFile(os.NewFile(uintptr(fds[1]), "theirs"))
+	t.Cleanup(func() {
+		ours.Close()
+		theirs.Close()
+	})
+
+	return ours, theirs
+}
+
+func TestTextFileCanWriteAfterReadingFromANonSeekableFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	ours, theirs := newSocketPairTextFiles(t)
+	theirs.WriteString("hello\nworld\n")
+
+	// this reads ahead, and buffers "world\n"
+	line1, err1 := ours.ReadLine()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err2 := ours.WriteString("reply\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Equal(t, "hello\n", line1)
+	if !assert.Nil(t, err2) {
+		// the peer will never see a reply
+		return
+	}
+
+	// we have not lost the data that we read ahead
+	line2, err3 := ours.ReadLine()
+	assert.Nil(t, err3)
+	assert.Equal(t, "world\n", line2)
+
+	reply, err4 := theirs.ReadLine()
+	assert.Nil(t, err4)
+	assert.Equal(t, "reply\n", reply)
+}
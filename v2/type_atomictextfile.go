@@ -0,0 +1,179 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// AtomicTextFile is a TextFile that only replaces its target file when
+// you call Commit().
+//
+// All writes go to a temporary file in the same directory as the target.
+// Commit() flushes the temporary file to disk, and renames it over the
+// target. If you call Abort(), or Close() without calling Commit(), the
+// temporary file is deleted, and the target file is left untouched.
+type AtomicTextFile struct {
+	*TextFile
+
+	// target is the file that Commit() will replace
+	target string
+
+	// flags holds our state; `closed` is set once Commit() or Abort()
+	// has run
+	flags int
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// CreateAtomicTextFile creates a temporary file next to the named file,
+// and wraps it in an AtomicTextFile. The named file is not touched until
+// you call Commit().
+//
+// perm sets the permissions of the named file after Commit().
+func CreateAtomicTextFile(name string, perm os.FileMode) (*AtomicTextFile, error) {
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.Chmod(perm)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	retval := AtomicTextFile{
+		TextFile: NewTextFile(f),
+		target:   name,
+	}
+
+	// all done
+	return &retval, nil
+}
+
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// TargetName returns the name of the file that Commit() will replace.
+//
+// Name() returns the name of the temporary file.
+func (d *AtomicTextFile) TargetName() string {
+	return d.target
+}
+
+// Commit flushes everything that has been written to disk (including
+// any partial UTF-8 character left over from the last write), and then
+// renames the temporary file over the target file. It also closes the
+// AtomicTextFile.
+//
+// If Commit fails, the temporary file is deleted and the target file is
+// left untouched.
+func (d *AtomicTextFile) Commit() error {
+	if d.flags&closed != 0 {
+		return os.ErrClosed
+	}
+	d.flags |= closed
+
+	tmpName := d.Name()
+
+	err := d.flushEncoder()
+	if err == nil {
+		err = d.Sync()
+	}
+	if err == nil {
+		err = d.TextFile.Close()
+	} else {
+		d.TextFile.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmpName, d.target)
+	}
+	if err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	// make sure that the rename itself survives a crash
+	//
+	// not every platform supports this, so we ignore any errors
+	dir, err := os.Open(filepath.Dir(d.target))
+	if err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+
+	return nil
+}
+
+// Abort closes and deletes the temporary file. The target file is left
+// untouched.
+func (d *AtomicTextFile) Abort() error {
+	if d.flags&closed != 0 {
+		return os.ErrClosed
+	}
+	d.flags |= closed
+
+	tmpName := d.Name()
+	d.TextFile.Close()
+
+	return os.Remove(tmpName)
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+// Close discards everything that has been written, unless Commit() has
+// already been called. It is safe to call Close() after Commit() or
+// Abort(), so that you can `defer` it.
+func (d *AtomicTextFile) Close() error {
+	if d.flags&closed != 0 {
+		return nil
+	}
+
+	return d.Abort()
+}
@@ -0,0 +1,262 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+func TestCreateAtomicTextFileDoesNotTouchTheTarget(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "config.txt")
+	os.WriteFile(name, []byte("original\n"), 0644)
+
+	expectedResult := "original\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit, err := CreateAtomicTextFile(name, 0644)
+	assert.Nil(t, err)
+	defer unit.Close()
+
+	unit.WriteLine("replacement")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	actualResult, _ := os.ReadFile(name)
+	assert.Equal(t, expectedResult, string(actualResult))
+	assert.Equal(t, name, unit.TargetName())
+	assert.Equal(t, filepath.Dir(name), filepath.Dir(unit.Name()))
+}
+
+// ================================================================
+//
+// Interface compatibility
+//
+// ----------------------------------------------------------------
+
+func TestAtomicTextFileImplementsTextReaderWriter(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := AtomicTextFile{}
+	var i interface{} = &unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(TextReaderWriter)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
+// ================================================================
+//
+// Commit / Abort
+//
+// ----------------------------------------------------------------
+
+func TestAtomicTextFileCommitReplacesTheTarget(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "config.txt")
+	os.WriteFile(name, []byte("original\n"), 0644)
+
+	unit, err := CreateAtomicTextFile(name, 0600)
+	assert.Nil(t, err)
+	defer unit.Close()
+	unit.WriteLine("replacement")
+
+	expectedResult := "replacement\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err = unit.Commit()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+
+	actualResult, _ := os.ReadFile(name)
+	assert.Equal(t, expectedResult, string(actualResult))
+
+	info, _ := os.Stat(name)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// the temporary file must not be left behind
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+}
+
+func TestAtomicTextFileCloseWithoutCommitDiscardsTheWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "config.txt")
+
+	unit, err := CreateAtomicTextFile(name, 0644)
+	assert.Nil(t, err)
+	unit.WriteLine("half-written")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err = unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+
+	_, err = os.Stat(name)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+}
+
+func TestAtomicTextFileAbortDiscardsTheWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "config.txt")
+	os.WriteFile(name, []byte("original\n"), 0644)
+
+	unit, err := CreateAtomicTextFile(name, 0644)
+	assert.Nil(t, err)
+	unit.WriteLine("replacement")
+
+	expectedResult := "original\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err = unit.Abort()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+
+	actualResult, _ := os.ReadFile(name)
+	assert.Equal(t, expectedResult, string(actualResult))
+
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+}
+
+func TestAtomicTextFileCommitAfterAbortFails(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "config.txt")
+
+	unit, err := CreateAtomicTextFile(name, 0644)
+	assert.Nil(t, err)
+	unit.Abort()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err = unit.Commit()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestAtomicTextFileSupportsReadingBackBeforeCommit(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "config.txt")
+
+	unit, err := CreateAtomicTextFile(name, 0644)
+	assert.Nil(t, err)
+	defer unit.Close()
+
+	unit.WriteLines([]string{"hello world", "have a nice day"})
+
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Rewind()
+	actualResult := unit.Strings()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
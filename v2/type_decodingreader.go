@@ -0,0 +1,329 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// decodingReader converts text from its original encoding into UTF-8,
+// removing any byte order mark as it goes.
+type decodingReader struct {
+	r io.Reader
+
+	// encoding is the encoding that we have been asked to use
+	encoding Encoding
+
+	// active is the encoding that we are actually using, once we have
+	// looked for a byte order mark
+	active Encoding
+
+	// strict tells us to report invalid data, instead of replacing it
+	strict bool
+
+	// utf8Policy tells us what to do with invalid UTF-8
+	utf8Policy UTF8Policy
+
+	// atStart is set until we have looked for a byte order mark
+	atStart bool
+
+	// chunk is where we read into
+	chunk []byte
+
+	// raw holds data that we have read, but not yet decoded
+	raw []byte
+
+	// out holds data that we have decoded, but not yet returned
+	out []byte
+
+	// offset is where raw[0] is, from the start of the data
+	offset int64
+
+	// readErr is the error returned by our io.Reader
+	readErr error
+
+	// decodeErr is the error found by strict mode
+	decodeErr error
+}
+
+// newDecodingReader creates a reader that decodes `r` from the given
+// encoding into UTF-8.
+func newDecodingReader(r io.Reader, encoding Encoding, strict bool, policy UTF8Policy) *decodingReader {
+	retval := decodingReader{
+		r:          r,
+		encoding:   encoding,
+		active:     encoding,
+		strict:     strict,
+		utf8Policy: policy,
+		atStart:    true,
+		chunk:      make([]byte, 4096),
+	}
+
+	// all done
+	return &retval
+}
+
+// reset throws away any data that we have buffered, ready for the
+// underlying io.Reader to be read from a new position. `atStart` says
+// whether or not that new position is the start of the data.
+func (d *decodingReader) reset(atStart bool, offset int64) {
+	d.raw = nil
+	d.out = nil
+	d.readErr = nil
+	d.decodeErr = nil
+	d.offset = offset
+	d.atStart = atStart
+	if atStart {
+		d.active = d.encoding
+	}
+}
+
+// unreadLen returns how many bytes of the underlying data we have read,
+// but not yet returned. `buffered` is any decoded data that our caller
+// has buffered, and not yet returned either.
+//
+// It is exact as long as the data is valid in its encoding.
+func (d *decodingReader) unreadLen(buffered []byte) int {
+	return len(d.raw) + d.active.encodedLen(d.out) + d.active.encodedLen(buffered)
+}
+
+// Read fills p with decoded UTF-8 text.
+func (d *decodingReader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.decodeErr != nil {
+			return 0, d.decodeErr
+		}
+		if d.readErr != nil && len(d.raw) == 0 {
+			// just like bufio.Reader, we try again after io.EOF, in
+			// case more data has arrived since
+			err := d.readErr
+			if err == io.EOF {
+				d.readErr = nil
+			}
+			return 0, err
+		}
+
+		if d.readErr == nil {
+			n, err := d.r.Read(d.chunk)
+			d.raw = append(d.raw, d.chunk[:n]...)
+			d.readErr = err
+		}
+
+		if d.atStart {
+			// we need 4 bytes to tell the UTF-32LE and UTF-16LE
+			// byte order marks apart
+			if len(d.raw) < 4 && d.readErr == nil {
+				continue
+			}
+			if len(d.raw) == 0 {
+				continue
+			}
+			var bomLen int
+			d.active, bomLen = d.encoding.detectBOM(d.raw)
+			d.consume(bomLen)
+			d.atStart = false
+		}
+
+		d.decodeErr = d.decode(d.readErr != nil)
+	}
+
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+
+	return n, nil
+}
+
+// consume removes the first n bytes from our raw data
+func (d *decodingReader) consume(n int) {
+	d.raw = d.raw[n:]
+	d.offset += int64(n)
+}
+
+// invalid deals with an invalid byte sequence at raw[i:i+size]. In strict
+// mode, it returns an error. Otherwise, it adds the unicode replacement
+// character to our output.
+func (d *decodingReader) invalid(i int, size int) error {
+	if d.strict {
+		return &EncodingError{
+			Encoding: d.active,
+			Offset:   d.offset + int64(i),
+			Err:      ErrInvalidByteSequence,
+		}
+	}
+
+	d.out = utf8.AppendRune(d.out, utf8.RuneError)
+	return nil
+}
+
+// decode converts as much of our raw data as possible into UTF-8. `final`
+// tells us that there is no more raw data to come, so any incomplete
+// character at the end is invalid.
+func (d *decodingReader) decode(final bool) error {
+	var err error
+	i := 0
+
+	switch d.active {
+	case EncodingUTF16LE, EncodingUTF16BE:
+		i, err = d.decodeUTF16(final)
+	case EncodingUTF32LE, EncodingUTF32BE:
+		i, err = d.decodeUTF32(final)
+	case EncodingISO88591, EncodingWindows1252:
+		i, err = d.decodeSingleByte()
+	default:
+		i, err = d.decodeUTF8(final)
+	}
+
+	d.consume(i)
+	return err
+}
+
+// byteOrder returns the byte order of our active encoding
+func (d *decodingReader) byteOrder() binary.ByteOrder {
+	if d.active == EncodingUTF16BE || d.active == EncodingUTF32BE {
+		return binary.BigEndian
+	}
+
+	return binary.LittleEndian
+}
+
+// decodeUTF8 checks our raw data against our UTF-8 policy
+func (d *decodingReader) decodeUTF8(final bool) (int, error) {
+	policy := d.utf8Policy
+	if d.strict && d.active != EncodingDefault {
+		policy = UTF8Error
+	}
+
+	var err error
+	var n int
+	d.out, n, err = policy.apply(d.out, d.raw, final, d.offset)
+
+	return n, err
+}
+
+// decodeUTF16 decodes our raw data as UTF-16
+func (d *decodingReader) decodeUTF16(final bool) (int, error) {
+	order := d.byteOrder()
+
+	i := 0
+	for i+2 <= len(d.raw) {
+		r := rune(order.Uint16(d.raw[i:]))
+		size := 2
+
+		if utf16.IsSurrogate(r) {
+			// wait for the rest of the surrogate pair
+			if i+4 > len(d.raw) && !final {
+				break
+			}
+
+			if i+4 <= len(d.raw) {
+				r = utf16.DecodeRune(r, rune(order.Uint16(d.raw[i+2:])))
+				size = 4
+			}
+
+			// unpaired surrogates are invalid
+			if r == utf8.RuneError || utf16.IsSurrogate(r) {
+				if err := d.invalid(i, 2); err != nil {
+					return i, err
+				}
+				i += 2
+				continue
+			}
+		}
+
+		d.out = utf8.AppendRune(d.out, r)
+		i += size
+	}
+
+	if final && i < len(d.raw) {
+		if err := d.invalid(i, len(d.raw)-i); err != nil {
+			return i, err
+		}
+		i = len(d.raw)
+	}
+
+	return i, nil
+}
+
+// decodeUTF32 decodes our raw data as UTF-32
+func (d *decodingReader) decodeUTF32(final bool) (int, error) {
+	order := d.byteOrder()
+
+	i := 0
+	for i+4 <= len(d.raw) {
+		r := rune(order.Uint32(d.raw[i:]))
+		if !utf8.ValidRune(r) {
+			if err := d.invalid(i, 4); err != nil {
+				return i, err
+			}
+		} else {
+			d.out = utf8.AppendRune(d.out, r)
+		}
+		i += 4
+	}
+
+	if final && i < len(d.raw) {
+		if err := d.invalid(i, len(d.raw)-i); err != nil {
+			return i, err
+		}
+		i = len(d.raw)
+	}
+
+	return i, nil
+}
+
+// decodeSingleByte decodes our raw data as ISO-8859-1 or Windows-1252
+func (d *decodingReader) decodeSingleByte() (int, error) {
+	for i, b := range d.raw {
+		r := rune(b)
+		if d.active == EncodingWindows1252 && b >= 0x80 && b <= 0x9F {
+			r = windows1252[b-0x80]
+			if r == 0 {
+				if d.strict {
+					return i, d.invalid(i, 1)
+				}
+
+				// like web browsers do, we map undefined bytes
+				// to the matching control character
+				r = rune(b)
+			}
+		}
+		d.out = utf8.AppendRune(d.out, r)
+	}
+
+	return len(d.raw), nil
+}
@@ -0,0 +1,190 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// Encoding tells TextIOWrapper and TextFile which character encoding the
+// underlying data uses.
+//
+// Our read methods decode the data to UTF-8, and our write methods encode
+// UTF-8 back into the chosen encoding.
+type Encoding int
+
+const (
+	// EncodingDefault treats the data as UTF-8, and passes it through
+	// untouched. Byte order marks are not removed, and strict mode has
+	// no effect. This is the default.
+	EncodingDefault Encoding = iota
+
+	// EncodingUTF8 treats the data as UTF-8, and removes any UTF-8 byte
+	// order mark from the start of the data.
+	EncodingUTF8
+
+	// EncodingUTF16LE decodes little-endian UTF-16, and removes any
+	// matching byte order mark from the start of the data.
+	EncodingUTF16LE
+
+	// EncodingUTF16BE decodes big-endian UTF-16, and removes any
+	// matching byte order mark from the start of the data.
+	EncodingUTF16BE
+
+	// EncodingUTF32LE decodes little-endian UTF-32, and removes any
+	// matching byte order mark from the start of the data.
+	EncodingUTF32LE
+
+	// EncodingUTF32BE decodes big-endian UTF-32, and removes any
+	// matching byte order mark from the start of the data.
+	EncodingUTF32BE
+
+	// EncodingISO88591 decodes ISO-8859-1 (Latin-1).
+	EncodingISO88591
+
+	// EncodingWindows1252 decodes Windows-1252 (the Windows superset
+	// of Latin-1).
+	EncodingWindows1252
+
+	// EncodingAuto looks for a UTF-8, UTF-16 or UTF-32 byte order mark
+	// at the start of the data, removes it, and decodes the rest of the
+	// data to match. Data without a byte order mark is treated as UTF-8.
+	//
+	// Our write methods always write UTF-8.
+	EncodingAuto
+)
+
+// String returns the usual name for the encoding, eg "UTF-16LE".
+func (e Encoding) String() string {
+	switch e {
+	case EncodingUTF8:
+		return "UTF-8"
+	case EncodingUTF16LE:
+		return "UTF-16LE"
+	case EncodingUTF16BE:
+		return "UTF-16BE"
+	case EncodingUTF32LE:
+		return "UTF-32LE"
+	case EncodingUTF32BE:
+		return "UTF-32BE"
+	case EncodingISO88591:
+		return "ISO-8859-1"
+	case EncodingWindows1252:
+		return "Windows-1252"
+	case EncodingAuto:
+		return "auto"
+	default:
+		return "default"
+	}
+}
+
+// ================================================================
+//
+// Byte order marks
+//
+// ----------------------------------------------------------------
+
+// byteOrderMarks lists each encoding's byte order mark, in the order that
+// EncodingAuto must test them: UTF-32LE's BOM starts with UTF-16LE's BOM
+var byteOrderMarks = []struct {
+	encoding Encoding
+	bom      []byte
+}{
+	{EncodingUTF32LE, []byte{0xFF, 0xFE, 0x00, 0x00}},
+	{EncodingUTF32BE, []byte{0x00, 0x00, 0xFE, 0xFF}},
+	{EncodingUTF8, []byte{0xEF, 0xBB, 0xBF}},
+	{EncodingUTF16LE, []byte{0xFF, 0xFE}},
+	{EncodingUTF16BE, []byte{0xFE, 0xFF}},
+}
+
+// detectBOM works out which byte order mark (if any) `data` starts with,
+// and what encoding we should use to decode the rest of `data`.
+func (e Encoding) detectBOM(data []byte) (Encoding, int) {
+	for _, mark := range byteOrderMarks {
+		if (e == EncodingAuto || e == mark.encoding) && bytes.HasPrefix(data, mark.bom) {
+			return mark.encoding, len(mark.bom)
+		}
+	}
+
+	if e == EncodingAuto {
+		return EncodingUTF8, 0
+	}
+
+	return e, 0
+}
+
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// isUTF8 returns true if the encoding reads and writes UTF-8
+func (e Encoding) isUTF8() bool {
+	return e == EncodingDefault || e == EncodingUTF8 || e == EncodingAuto
+}
+
+// encodedLen returns how many bytes the given UTF-8 text takes up, once
+// it has been encoded.
+func (e Encoding) encodedLen(text []byte) int {
+	switch e {
+	case EncodingUTF16LE, EncodingUTF16BE:
+		retval := 0
+		for _, r := range string(text) {
+			if r >= 0x10000 {
+				retval += 4
+			} else {
+				retval += 2
+			}
+		}
+		return retval
+	case EncodingUTF32LE, EncodingUTF32BE:
+		return 4 * utf8.RuneCount(text)
+	case EncodingISO88591, EncodingWindows1252:
+		return utf8.RuneCount(text)
+	default:
+		return len(text)
+	}
+}
+
+// windows1252 maps bytes 0x80 to 0x9F to their unicode characters. The
+// bytes that Windows-1252 does not define are mapped to 0.
+var windows1252 = [32]rune{
+	0x20AC, 0, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0, 0x017D, 0,
+	0, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0, 0x017E, 0x0178,
+}
@@ -0,0 +1,616 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodeUTF16 is a helper. It returns the given text as UTF-16, in the
+// given byte order.
+func encodeUTF16(text string, order binary.AppendByteOrder) []byte {
+	var retval []byte
+	for _, u := range utf16.Encode([]rune(text)) {
+		retval = order.AppendUint16(retval, u)
+	}
+
+	return retval
+}
+
+// newEncodedTextIOWrapper is a helper. It returns a TextIOWrapper around
+// a buffer holding the given data, using the given encoding.
+func newEncodedTextIOWrapper(data []byte, encoding Encoding, strict bool) (*TextIOWrapper, *bytes.Buffer) {
+	buf := bytes.NewBuffer(data)
+	retval := NewTextIOWrapper(NopReadWriteCloser(buf))
+	retval.SetEncoding(encoding)
+	retval.SetStrictEncoding(strict)
+
+	return retval, buf
+}
+
+// ================================================================
+//
+// Encoding
+//
+// ----------------------------------------------------------------
+
+func TestEncodingStringReturnsTheEncodingName(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := map[Encoding]string{
+		EncodingDefault:     "default",
+		EncodingUTF8:        "UTF-8",
+		EncodingUTF16LE:     "UTF-16LE",
+		EncodingUTF16BE:     "UTF-16BE",
+		EncodingUTF32LE:     "UTF-32LE",
+		EncodingUTF32BE:     "UTF-32BE",
+		EncodingISO88591:    "ISO-8859-1",
+		EncodingWindows1252: "Windows-1252",
+		EncodingAuto:        "auto",
+	}
+
+	for encoding, expectedResult := range testData {
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := encoding.String()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult)
+	}
+}
+
+// ================================================================
+//
+// Decoding
+//
+// ----------------------------------------------------------------
+
+func TestEncodingAutoDetectsAndStripsByteOrderMarks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	text := "héllo\nwörld €\U0001F600\n"
+	utf32le := []byte{0xFF, 0xFE, 0x00, 0x00}
+	utf32be := []byte{0x00, 0x00, 0xFE, 0xFF}
+	for _, r := range text {
+		utf32le = binary.LittleEndian.AppendUint32(utf32le, uint32(r))
+		utf32be = binary.BigEndian.AppendUint32(utf32be, uint32(r))
+	}
+
+	testData := map[string][]byte{
+		"UTF-8":    append([]byte{0xEF, 0xBB, 0xBF}, text...),
+		"UTF-16LE": append([]byte{0xFF, 0xFE}, encodeUTF16(text, binary.LittleEndian)...),
+		"UTF-16BE": append([]byte{0xFE, 0xFF}, encodeUTF16(text, binary.BigEndian)...),
+		"UTF-32LE": utf32le,
+		"UTF-32BE": utf32be,
+		"no BOM":   []byte(text),
+	}
+	expectedResult := []string{"héllo", "wörld €\U0001F600"}
+
+	for name, input := range testData {
+		unit, _ := newEncodedTextIOWrapper(input, EncodingAuto, true)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := []string{}
+		for line := range unit.ReadLines() {
+			actualResult = append(actualResult, line)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult, name)
+	}
+}
+
+func TestEncodingDefaultDoesNotStripTheByteOrderMark(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	input := "\xef\xbb\xbfhello world\n"
+	unit, _ := newEncodedTextIOWrapper([]byte(input), EncodingDefault, true)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := unit.String()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, input, actualResult)
+}
+
+func TestEncodingUTF16BEDecodesWithoutAByteOrderMark(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	input := encodeUTF16("hello\nworld\n", binary.BigEndian)
+	unit, _ := newEncodedTextIOWrapper(input, EncodingUTF16BE, true)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	line1, err1 := unit.ReadLine()
+	line2, err2 := unit.ReadLine()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Equal(t, "hello\n", line1)
+	assert.Nil(t, err2)
+	assert.Equal(t, "world\n", line2)
+}
+
+func TestEncodingISO88591DecodesLatin1(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, _ := newEncodedTextIOWrapper([]byte("caf\xe9 cr\xe8me\n"), EncodingISO88591, true)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := unit.ReadAllString()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "café crème\n", actualResult)
+}
+
+func TestEncodingWindows1252DecodesSmartQuotesAndTheEuroSign(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, _ := newEncodedTextIOWrapper([]byte("\x93caf\xe9\x94 \x80\n"), EncodingWindows1252, true)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := unit.ReadAllString()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "“café” €\n", actualResult)
+}
+
+func TestEncodingStrictModeReportsInvalidData(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := []struct {
+		name           string
+		input          []byte
+		encoding       Encoding
+		expectedText   string
+		expectedOffset int64
+	}{
+		{
+			name:           "UTF-8",
+			input:          []byte("hello \xff world"),
+			encoding:       EncodingUTF8,
+			expectedText:   "hello ",
+			expectedOffset: 6,
+		},
+		{
+			name:           "UTF-16LE lone surrogate",
+			input:          append(encodeUTF16("hi", binary.LittleEndian), 0x00, 0xDC, 'x', 0),
+			encoding:       EncodingUTF16LE,
+			expectedText:   "hi",
+			expectedOffset: 4,
+		},
+		{
+			name:           "UTF-16LE odd length",
+			input:          append(encodeUTF16("hi", binary.LittleEndian), 'x'),
+			encoding:       EncodingUTF16LE,
+			expectedText:   "hi",
+			expectedOffset: 4,
+		},
+		{
+			name:           "Windows-1252 undefined byte",
+			input:          []byte("abc\x81def"),
+			encoding:       EncodingWindows1252,
+			expectedText:   "abc",
+			expectedOffset: 3,
+		},
+	}
+
+	for _, testCase := range testData {
+		unit, _ := newEncodedTextIOWrapper(testCase.input, testCase.encoding, true)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualText, err := io.ReadAll(unit)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.ErrorIs(t, err, ErrInvalidByteSequence, testCase.name)
+		var encErr *EncodingError
+		if assert.True(t, errors.As(err, &encErr), testCase.name) {
+			assert.Equal(t, testCase.encoding, encErr.Encoding, testCase.name)
+			assert.Equal(t, testCase.expectedOffset, encErr.Offset, testCase.name)
+		}
+		assert.Equal(t, testCase.expectedText, string(actualText), testCase.name)
+	}
+}
+
+func TestEncodingLenientModeReplacesInvalidData(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	input := append(encodeUTF16("hi", binary.LittleEndian), 0x00, 0xDC, 'x', 0)
+	unit, _ := newEncodedTextIOWrapper(input, EncodingUTF16LE, false)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := unit.ReadAllString()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hi�x", actualResult)
+}
+
+// ================================================================
+//
+// Encoding
+//
+// ----------------------------------------------------------------
+
+func TestEncodingUTF16LEEncodesWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, buf := newEncodedTextIOWrapper(nil, EncodingUTF16LE, true)
+	expectedResult := encodeUTF16("héllo\n\U0001F600", binary.LittleEndian)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err1 := unit.WriteLine("héllo")
+	_, err2 := unit.WriteRune('\U0001F600')
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, expectedResult, buf.Bytes())
+}
+
+func TestEncodingWritesCopeWithCharactersSplitAcrossWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, buf := newEncodedTextIOWrapper(nil, EncodingISO88591, true)
+	text := []byte("café")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	n1, err1 := unit.Write(text[:4])
+	n2, err2 := unit.Write(text[4:])
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, 4, n1)
+	assert.Equal(t, 1, n2)
+	assert.Equal(t, []byte("caf\xe9"), buf.Bytes())
+}
+
+func TestEncodingWindows1252EncodesWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, buf := newEncodedTextIOWrapper(nil, EncodingWindows1252, true)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.WriteString("“café” €")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("\x93caf\xe9\x94 \x80"), buf.Bytes())
+}
+
+func TestEncodingStrictModeReportsUnrepresentableCharacters(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, buf := newEncodedTextIOWrapper(nil, EncodingISO88591, true)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	n, err := unit.WriteString("price: €10")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, ErrUnrepresentableRune)
+	var encErr *EncodingError
+	if assert.True(t, errors.As(err, &encErr)) {
+		assert.Equal(t, int64(7), encErr.Offset)
+	}
+	assert.Equal(t, 7, n)
+	assert.Equal(t, "price: ", buf.String())
+}
+
+func TestEncodingLenientModeReplacesUnrepresentableCharacters(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, buf := newEncodedTextIOWrapper(nil, EncodingISO88591, false)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	n, err := unit.WriteString("price: €10")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 12, n)
+	assert.Equal(t, "price: ?10", buf.String())
+}
+
+// ================================================================
+//
+// TextFile
+//
+// ----------------------------------------------------------------
+
+func TestEncodingTextFileRoundTripsUTF16(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextFile(createTestFile(""))
+	defer unit.Close()
+	unit.SetEncoding(EncodingUTF16LE)
+	expectedResult := []string{"héllo", "wörld"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.WriteLines([]string{"héllo", "wörld"})
+	unit.Rewind()
+
+	actualResult := []string{}
+	for line := range unit.ReadLines() {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestEncodingTextFileTracksPositionInTheUnderlyingFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	content := append([]byte{0xFF, 0xFE}, encodeUTF16("héllo\nwörld\n", binary.LittleEndian)...)
+	unit := NewTextFile(createTestFile(string(content)))
+	defer unit.Close()
+	unit.SetEncoding(EncodingAuto)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	line, err := unit.ReadLine()
+	pos, seekErr := unit.Seek(0, io.SeekCurrent)
+	line2, err2 := unit.ReadLine()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "héllo\n", line)
+	assert.Nil(t, seekErr)
+
+	// 2 bytes of BOM, followed by 6 UTF-16 characters
+	assert.Equal(t, int64(14), pos)
+	assert.Nil(t, err2)
+	assert.Equal(t, "wörld\n", line2)
+}
+
+func TestEncodingTextFileWritesAfterTheDataThatHasBeenRead(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	content := encodeUTF16("héllo\nwörld\n", binary.LittleEndian)
+	unit := NewTextFile(createTestFile(string(content)))
+	defer unit.Close()
+	unit.SetEncoding(EncodingUTF16LE)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.ReadLine()
+	unit.WriteString("X\n")
+	unit.Rewind()
+	actualResult, err := unit.ReadAllString()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "héllo\nX\nrld\n", actualResult)
+}
+
+func TestEncodingCloseWritesOutAPartialCharacter(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "utf16.txt")
+	unit, err := CreateTextFile(name)
+	assert.Nil(t, err)
+	unit.SetEncoding(EncodingUTF16LE)
+	expectedResult := encodeUTF16("hi�", binary.LittleEndian)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	n, writeErr := unit.Write([]byte("hi\xe2"))
+	closeErr := unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, 3, n)
+	assert.Nil(t, writeErr)
+	assert.Nil(t, closeErr)
+
+	actualResult, _ := os.ReadFile(name)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestEncodingStrictModeReportsAPartialCharacterOnClose(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, buf := newEncodedTextIOWrapper(nil, EncodingUTF16BE, true)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Write([]byte("hi\xe2\x82"))
+	err := unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, ErrInvalidByteSequence)
+	var encErr *EncodingError
+	if assert.True(t, errors.As(err, &encErr)) {
+		assert.Equal(t, int64(2), encErr.Offset)
+	}
+	assert.Equal(t, encodeUTF16("hi", binary.BigEndian), buf.Bytes())
+}
+
+func TestEncodingAtomicTextFileCommitWritesOutAPartialCharacter(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	name := filepath.Join(t.TempDir(), "utf16.txt")
+	unit, err := CreateAtomicTextFile(name, 0644)
+	assert.Nil(t, err)
+	defer unit.Close()
+	unit.SetEncoding(EncodingUTF16LE)
+	expectedResult := encodeUTF16("ok��", binary.LittleEndian)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Write([]byte("ok\xf0\x9f"))
+	err = unit.Commit()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	actualResult, _ := os.ReadFile(name)
+	assert.Equal(t, expectedResult, actualResult)
+}
@@ -0,0 +1,79 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidByteSequence is returned (wrapped in an EncodingError) when
+// strict mode finds data that is not valid in its encoding.
+var ErrInvalidByteSequence = errors.New("invalid byte sequence")
+
+// ErrUnrepresentableRune is returned (wrapped in an EncodingError) when
+// strict mode is asked to write a character that its encoding cannot
+// represent.
+var ErrUnrepresentableRune = errors.New("character cannot be represented")
+
+// ErrInvalidRune is returned (wrapped in an EncodingError) when strict
+// mode, or UTF8Error, is asked to write a rune that is not a valid
+// unicode character.
+var ErrInvalidRune = errors.New("invalid rune")
+
+// EncodingError reports where strict mode (or UTF8Error) found a problem
+// with the data that it was decoding or encoding.
+type EncodingError struct {
+	// Encoding is the encoding that we were using
+	Encoding Encoding
+
+	// Offset is the position of the problem, in bytes, from the start
+	// of the data
+	Offset int64
+
+	// Err is the problem that we found
+	Err error
+}
+
+// Error returns a human-readable description of the problem.
+func (e *EncodingError) Error() string {
+	return fmt.Sprintf("%s: %v at offset %d", e.Encoding, e.Err, e.Offset)
+}
+
+// Unwrap returns the problem that we found, for use with errors.Is()
+// and errors.As().
+func (e *EncodingError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,247 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// encodingWriter converts UTF-8 text into its destination encoding.
+type encodingWriter struct {
+	w io.Writer
+
+	// encoding is what we convert the text into
+	encoding Encoding
+
+	// strict tells us to report problems, instead of replacing the
+	// characters that we cannot convert
+	strict bool
+
+	// utf8Policy tells us what to do with invalid UTF-8
+	utf8Policy UTF8Policy
+
+	// pending holds an incomplete UTF-8 character, left over from the
+	// end of the last write
+	pending []byte
+
+	// offset is how many bytes of text we have converted so far
+	offset int64
+}
+
+// newEncodingWriter creates a writer that encodes UTF-8 text into the
+// given encoding before writing it to `w`.
+func newEncodingWriter(w io.Writer, encoding Encoding, strict bool, policy UTF8Policy) *encodingWriter {
+	if strict && encoding != EncodingDefault {
+		policy = UTF8Error
+	}
+
+	retval := encodingWriter{
+		w:          w,
+		encoding:   encoding,
+		strict:     strict,
+		utf8Policy: policy,
+	}
+
+	// all done
+	return &retval
+}
+
+// Write converts p into our encoding, and writes it to our io.Writer.
+//
+// If p ends part-way through a UTF-8 character, we hold on to that
+// partial character until the next call to Write, or Flush.
+func (d *encodingWriter) Write(p []byte) (int, error) {
+	return d.write(p, false)
+}
+
+// Flush writes out any partial UTF-8 character left over from the last
+// call to Write. No more data is coming, so it is invalid UTF-8: it is
+// replaced, passed through or reported, according to our UTF-8 policy.
+func (d *encodingWriter) Flush() error {
+	if len(d.pending) == 0 {
+		return nil
+	}
+
+	_, err := d.write(nil, true)
+	return err
+}
+
+// write converts p into our encoding, and writes it to our io.Writer.
+// `final` tells us that no more data is coming, so that any incomplete
+// UTF-8 character at the end of p is invalid.
+func (d *encodingWriter) write(p []byte, final bool) (int, error) {
+	text := p
+	if len(d.pending) > 0 {
+		text = append(d.pending, p...)
+	}
+
+	out := make([]byte, 0, len(text)*2)
+	i := 0
+	var encErr error
+	for i < len(text) {
+		if !final && !utf8.FullRune(text[i:]) {
+			break
+		}
+
+		r, size := utf8.DecodeRune(text[i:])
+		if r == utf8.RuneError && size == 1 {
+			if d.utf8Policy == UTF8Error {
+				encErr = d.newError(i, ErrInvalidByteSequence)
+				break
+			}
+			if d.utf8Policy == UTF8PassThrough && d.encoding.isUTF8() {
+				// pass the invalid byte through untouched
+				out = append(out, text[i])
+				i++
+				continue
+			}
+		}
+
+		var ok bool
+		out, ok = d.appendRune(out, r)
+		if !ok {
+			encErr = d.newError(i, ErrUnrepresentableRune)
+			break
+		}
+		i += size
+	}
+
+	// how much of p have we dealt with?
+	n := max(0, i-len(d.pending))
+	if encErr == nil {
+		n = len(p)
+		d.pending = append([]byte(nil), text[i:]...)
+	} else {
+		d.pending = nil
+	}
+	d.offset += int64(i)
+
+	if len(out) > 0 {
+		if _, err := d.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, encErr
+}
+
+// checkRune returns an error if r is not a valid unicode character, and
+// we have been told to report that.
+func (d *encodingWriter) checkRune(r rune) error {
+	if utf8.ValidRune(r) || d.utf8Policy != UTF8Error {
+		return nil
+	}
+
+	return d.newError(len(d.pending), ErrInvalidRune)
+}
+
+// newError returns an EncodingError for the character at text[i]
+func (d *encodingWriter) newError(i int, err error) error {
+	encoding := d.encoding
+	if encoding.isUTF8() {
+		encoding = EncodingUTF8
+	}
+
+	return &EncodingError{
+		Encoding: encoding,
+		Offset:   d.offset + int64(i),
+		Err:      err,
+	}
+}
+
+// appendRune adds the encoded form of r to out. It returns false if
+// (in strict mode) r cannot be encoded.
+func (d *encodingWriter) appendRune(out []byte, r rune) ([]byte, bool) {
+	switch d.encoding {
+	case EncodingUTF16LE, EncodingUTF16BE:
+		order := d.byteOrder()
+		if r >= 0x10000 {
+			r1, r2 := utf16.EncodeRune(r)
+			out = order.AppendUint16(out, uint16(r1))
+			return order.AppendUint16(out, uint16(r2)), true
+		}
+		return order.AppendUint16(out, uint16(r)), true
+	case EncodingUTF32LE, EncodingUTF32BE:
+		return d.byteOrder().AppendUint32(out, uint32(r)), true
+	case EncodingISO88591, EncodingWindows1252:
+		b, ok := d.singleByte(r)
+		if !ok {
+			if d.strict {
+				return out, false
+			}
+			b = '?'
+		}
+		return append(out, b), true
+	default:
+		return utf8.AppendRune(out, r), true
+	}
+}
+
+// byteOrder returns the byte order of our encoding
+func (d *encodingWriter) byteOrder() binary.AppendByteOrder {
+	if d.encoding == EncodingUTF16BE || d.encoding == EncodingUTF32BE {
+		return binary.BigEndian
+	}
+
+	return binary.LittleEndian
+}
+
+// singleByte converts r into ISO-8859-1 or Windows-1252
+func (d *encodingWriter) singleByte(r rune) (byte, bool) {
+	if d.encoding == EncodingISO88591 {
+		return byte(r), r <= 0xFF
+	}
+
+	if r < 0x80 || (r >= 0xA0 && r <= 0xFF) {
+		return byte(r), true
+	}
+
+	for i, candidate := range windows1252 {
+		if candidate == r {
+			return byte(0x80 + i), true
+		}
+	}
+
+	// the bytes that Windows-1252 does not define are decoded to the
+	// matching control character, so we must accept those back
+	if !d.strict && r >= 0x80 && r <= 0x9F && windows1252[r-0x80] == 0 {
+		return byte(r), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,320 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// ErrExpectTimeout is returned (wrapped) by Expecter.Expect() and
+// Expecter.ExpectRegexp() when the expected text does not arrive in time.
+var ErrExpectTimeout = errors.New("timed out waiting for expected text")
+
+// ErrExpecterClosed is returned by Expecter.Expect() and
+// Expecter.ExpectRegexp() after Expecter.Close() has been called.
+var ErrExpecterClosed = errors.New("expecter has been closed")
+
+// expectPollInterval is how long a polling Expecter waits before reading
+// again, after its TextReader has reported io.EOF
+const expectPollInterval = 10 * time.Millisecond
+
+// ExpectResult is what Expecter.Expect() and Expecter.ExpectRegexp()
+// found in the incoming text.
+type ExpectResult struct {
+	// Before is all of the incoming text between the end of the previous
+	// match and the start of this one
+	Before string
+
+	// Match is the text that matched
+	Match string
+
+	// Groups holds the text of any parenthesized subexpressions in the
+	// regular expression. It is always empty for Expect().
+	Groups []string
+}
+
+// Expecter drives a scripted, expect-style conversation with a program
+// that prompts for, and reads, its answers: it waits for text to arrive
+// on a TextReader, and sends replies to a TextWriter.
+//
+// Once you have started expecting, the Expecter reads from its TextReader
+// in the background. Do not read from it, or close it, yourself, until
+// the other end of the conversation has closed its side.
+//
+// By default, io.EOF from the TextReader means that the other end of the
+// conversation has closed its side, and any Expect() call that is still
+// waiting returns io.EOF. Use NewPollingExpecterPair() if you want to
+// treat io.EOF as 'no data yet' instead.
+//
+// It is NOT safe for concurrent use: drive each conversation from a
+// single goroutine.
+type Expecter struct {
+	in  TextReader
+	out TextWriter
+
+	// buf holds incoming text that has not yet been matched
+	buf []byte
+
+	// err is the (sticky) error reported by our TextReader
+	err error
+
+	// pollOnEOF is set if io.EOF from our TextReader means 'no data
+	// yet', rather than the end of the conversation
+	pollOnEOF bool
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	chunks    chan expectChunk
+	done      chan struct{}
+}
+
+// expectChunk is what our background reader sends us
+type expectChunk struct {
+	data []byte
+	err  error
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewExpecter creates an Expecter that reads from, and replies to, the
+// given TextReaderWriter (eg, a TextIOWrapper around a pipe).
+func NewExpecter(rw TextReaderWriter) *Expecter {
+	return NewExpecterPair(rw, rw)
+}
+
+// NewExpecterPair creates an Expecter that reads from `in`, and replies
+// to `out` (eg, a pair of TextBuffers).
+func NewExpecterPair(in TextReader, out TextWriter) *Expecter {
+	retval := Expecter{
+		in:     in,
+		out:    out,
+		chunks: make(chan expectChunk),
+		done:   make(chan struct{}),
+	}
+
+	// all done
+	return &retval
+}
+
+// NewPollingExpecterPair creates an Expecter that reads from `in`, and
+// replies to `out`, just like NewExpecterPair() does.
+//
+// Unlike NewExpecterPair(), it treats io.EOF from `in` as 'no data yet',
+// and tries again shortly afterwards. This allows it to work with
+// TextBuffers that are being written to by another goroutine; use
+// NewSyncTextBuffer() to make that safe.
+func NewPollingExpecterPair(in TextReader, out TextWriter) *Expecter {
+	retval := NewExpecterPair(in, out)
+	retval.pollOnEOF = true
+
+	// all done
+	return retval
+}
+
+// ================================================================
+//
+// Expectations
+//
+// ----------------------------------------------------------------
+
+// Expect waits for the given literal text to arrive, for up to `timeout`.
+//
+// If the text arrives, everything up to and including it is consumed.
+// If it does not arrive in time, the returned error wraps
+// ErrExpectTimeout. If the other end closes its side first, the
+// returned error is io.EOF.
+func (d *Expecter) Expect(text string, timeout time.Duration) (ExpectResult, error) {
+	needle := []byte(text)
+	matcher := func(buf []byte) []int {
+		start := bytes.Index(buf, needle)
+		if start < 0 {
+			return nil
+		}
+		return []int{start, start + len(needle)}
+	}
+
+	return d.expect(matcher, fmt.Sprintf("%q", text), timeout)
+}
+
+// ExpectRegexp waits for text that matches the given regular expression
+// to arrive, for up to `timeout`.
+//
+// The regexp is tested against the incoming text as it arrives, so
+// open-ended patterns such as `\d+` may match before all of the text
+// has arrived.
+//
+// If the text arrives, everything up to and including it is consumed.
+// If it does not arrive in time, the returned error wraps
+// ErrExpectTimeout. If the other end closes its side first, the
+// returned error is io.EOF.
+func (d *Expecter) ExpectRegexp(re *regexp.Regexp, timeout time.Duration) (ExpectResult, error) {
+	return d.expect(re.FindSubmatchIndex, fmt.Sprintf("/%s/", re), timeout)
+}
+
+// expect waits for the matcher to find something in our incoming text.
+//
+// The matcher returns the same []int as regexp.FindSubmatchIndex(), or
+// nil if there is no match.
+func (d *Expecter) expect(matcher func([]byte) []int, desc string, timeout time.Duration) (ExpectResult, error) {
+	d.startOnce.Do(func() {
+		go d.readInBackground()
+	})
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		loc := matcher(d.buf)
+		if loc != nil {
+			return d.consume(loc), nil
+		}
+
+		if d.err != nil {
+			return ExpectResult{}, d.err
+		}
+
+		select {
+		case chunk := <-d.chunks:
+			d.buf = append(d.buf, chunk.data...)
+			d.err = chunk.err
+		case <-timer.C:
+			return ExpectResult{}, fmt.Errorf("%w %s after %s", ErrExpectTimeout, desc, timeout)
+		case <-d.done:
+			return ExpectResult{}, ErrExpecterClosed
+		}
+	}
+}
+
+// consume removes the matched text (and everything before it) from
+// our incoming text, and tells the caller what it was.
+func (d *Expecter) consume(loc []int) ExpectResult {
+	retval := ExpectResult{
+		Before: string(d.buf[:loc[0]]),
+		Match:  string(d.buf[loc[0]:loc[1]]),
+	}
+
+	for i := 2; i+1 < len(loc); i += 2 {
+		group := ""
+		if loc[i] >= 0 {
+			group = string(d.buf[loc[i]:loc[i+1]])
+		}
+		retval.Groups = append(retval.Groups, group)
+	}
+
+	d.buf = d.buf[loc[1]:]
+
+	// all done
+	return retval
+}
+
+// readInBackground copies everything from our TextReader to our
+// chunks channel, until we are closed or the TextReader fails (or
+// reports io.EOF, unless we are polling).
+func (d *Expecter) readInBackground() {
+	p := make([]byte, 4096)
+	for {
+		n, err := d.in.Read(p)
+		if n > 0 {
+			chunk := expectChunk{data: append([]byte(nil), p[:n]...)}
+			select {
+			case d.chunks <- chunk:
+			case <-d.done:
+				return
+			}
+		}
+
+		switch {
+		case (err == io.EOF && d.pollOnEOF) || (err == nil && n == 0):
+			select {
+			case <-time.After(expectPollInterval):
+			case <-d.done:
+				return
+			}
+		case err != nil:
+			select {
+			case d.chunks <- expectChunk{err: err}:
+			case <-d.done:
+			}
+			return
+		}
+	}
+}
+
+// ================================================================
+//
+// Replies
+//
+// ----------------------------------------------------------------
+
+// Send writes the given text to our TextWriter, as-is.
+func (d *Expecter) Send(text string) error {
+	_, err := d.out.WriteString(text)
+	return err
+}
+
+// SendLine writes the given text to our TextWriter, followed by
+// DefaultLineTerminator.
+func (d *Expecter) SendLine(line string) error {
+	return d.Send(line + DefaultLineTerminator)
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+// Close stops the Expecter from reading any further text. It does NOT
+// close the underlying TextReader or TextWriter.
+//
+// If our background reader is blocked in a Read() call, it will stop
+// once that call returns.
+func (d *Expecter) Close() error {
+	d.closeOnce.Do(func() {
+		close(d.done)
+	})
+
+	return nil
+}
@@ -0,0 +1,380 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pipeReadWriteCloser is a helper. It joins the ends of two io.Pipes
+// into a single io.ReadWriteCloser.
+type pipeReadWriteCloser struct {
+	*io.PipeReader
+	*io.PipeWriter
+}
+
+func (p pipeReadWriteCloser) Close() error {
+	p.PipeReader.Close()
+	return p.PipeWriter.Close()
+}
+
+// newPipeTextIOWrappers is a helper. It returns two TextIOWrappers that
+// talk to each other.
+func newPipeTextIOWrappers() (*TextIOWrapper, *TextIOWrapper) {
+	r1, w1 := io.Pipe()
+	r2, w2 := io.Pipe()
+
+	return NewTextIOWrapper(pipeReadWriteCloser{r1, w2}),
+		NewTextIOWrapper(pipeReadWriteCloser{r2, w1})
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+func TestNewExpecterWorks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit := NewExpecter(NewTextBuffer())
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, unit)
+}
+
+func TestNewPollingExpecterPairWorks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit := NewPollingExpecterPair(NewTextBuffer(), NewTextBuffer())
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, unit)
+	assert.True(t, unit.pollOnEOF)
+}
+
+// ================================================================
+//
+// Expectations
+//
+// ----------------------------------------------------------------
+
+func TestExpecterExpectReturnsTheMatchAndTheTextBeforeIt(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	in := NewTextBuffer()
+	in.WriteString("Welcome!\nName: Age: ")
+	out := NewTextBuffer()
+	unit := NewExpecterPair(in, out)
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	result1, err1 := unit.Expect("Name: ", time.Second)
+	result2, err2 := unit.Expect("Age: ", time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Equal(t, "Welcome!\n", result1.Before)
+	assert.Equal(t, "Name: ", result1.Match)
+	assert.Nil(t, err2)
+	assert.Equal(t, "", result2.Before)
+	assert.Equal(t, "Age: ", result2.Match)
+}
+
+func TestExpecterExpectRegexpReturnsTheMatchAndAnyGroups(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	in := NewTextBuffer()
+	in.WriteString("connecting ...\nserver version 2.17 ready\n")
+	unit := NewExpecterPair(in, NewTextBuffer())
+	defer unit.Close()
+
+	re := regexp.MustCompile(`version (\d+)\.(\d+) ready`)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	result, err := unit.ExpectRegexp(re, time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "connecting ...\nserver ", result.Before)
+	assert.Equal(t, "version 2.17 ready", result.Match)
+	assert.Equal(t, []string{"2", "17"}, result.Groups)
+}
+
+func TestExpecterExpectTimesOut(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	in := NewTextBuffer()
+	in.WriteString("Name: ")
+	unit := NewPollingExpecterPair(in, NewTextBuffer())
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.Expect("Password: ", 50*time.Millisecond)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+}
+
+func TestExpecterExpectWaitsForTextWrittenLater(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	in := NewSyncTextBuffer()
+	unit := NewPollingExpecterPair(in, NewTextBuffer())
+	defer unit.Close()
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		in.WriteString("Continue? [y/n] ")
+	}()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	result, err := unit.Expect("[y/n] ", time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Continue? ", result.Before)
+}
+
+func TestExpecterExpectReturnsEOFWhenTheInputRunsOut(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	in := NewTextBuffer()
+	in.WriteString("Name: ")
+	unit := NewExpecterPair(in, NewTextBuffer())
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.Expect("Password: ", time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestExpecterExpectReturnsEOFWhenThePeerCloses(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	ours, theirs := newPipeTextIOWrappers()
+	unit := NewExpecter(ours)
+	defer unit.Close()
+
+	go func() {
+		theirs.WriteString("Name: ")
+		theirs.Close()
+	}()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err1 := unit.Expect("Name: ", time.Second)
+	_, err2 := unit.Expect("Password: ", time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Equal(t, io.EOF, err2)
+}
+
+func TestExpecterExpectReturnsReadErrors(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedErr := errors.New("read failed")
+	in := NewTextIOWrapper(newFailingReadWriteCloser("Name: ", expectedErr))
+	unit := NewExpecterPair(in, NewTextBuffer())
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	result, err1 := unit.Expect("Name: ", time.Second)
+	_, err2 := unit.Expect("Age: ", time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Equal(t, "Name: ", result.Match)
+	assert.Equal(t, expectedErr, err2)
+}
+
+func TestExpecterExpectFailsAfterClose(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewExpecterPair(NewTextBuffer(), NewTextBuffer())
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Close()
+	_, err := unit.Expect("Name: ", time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, ErrExpecterClosed, err)
+}
+
+// ================================================================
+//
+// Replies
+//
+// ----------------------------------------------------------------
+
+func TestExpecterSendLineWritesAReplyLine(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	out := NewTextBuffer()
+	unit := NewExpecterPair(NewTextBuffer(), out)
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := unit.SendLine("Stuart")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Stuart\n", out.String())
+}
+
+func TestExpecterCanScriptAConversationOverPipes(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	ours, theirs := newPipeTextIOWrappers()
+
+	// this is the 'CLI tool' that we are testing
+	go func() {
+		defer theirs.Close()
+		theirs.WriteString("Name: ")
+		name, _ := theirs.ReadLine()
+		theirs.WriteString("Hello, " + name)
+		theirs.WriteString("Bye!\n")
+	}()
+
+	unit := NewExpecter(ours)
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err1 := unit.Expect("Name: ", time.Second)
+	err2 := unit.SendLine("Stuart")
+	result, err3 := unit.Expect("Bye!", time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, "Hello, Stuart\n", result.Before)
+}
@@ -0,0 +1,115 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInjectedFault is the error returned by FaultyReadWriteCloser, unless
+// you tell it to return a different error.
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultOptions tells a FaultyReadWriteCloser which faults to inject.
+//
+// The zero value injects no faults at all.
+type FaultOptions struct {
+	// ReadErr is the error returned by any injected read fault.
+	//
+	// Set it to nil to use ErrInjectedFault.
+	ReadErr error
+
+	// WriteErr is the error returned by any injected write fault.
+	//
+	// Set it to nil to use ErrInjectedFault.
+	WriteErr error
+
+	// CloseErr, if set, is returned by Close(). The underlying
+	// io.ReadWriteCloser is still closed.
+	CloseErr error
+
+	// FailReadAfterBytes, if greater than 0, is how many bytes can be
+	// read before every read fails with ReadErr.
+	FailReadAfterBytes int64
+
+	// FailWriteAfterBytes, if greater than 0, is how many bytes can be
+	// written before every write fails with WriteErr. The write that
+	// crosses the limit is a short write.
+	FailWriteAfterBytes int64
+
+	// FailReadOnCall, if greater than 0, makes that call to Read() (the
+	// first call is 1) fail with ReadErr. Later calls work as normal.
+	FailReadOnCall int
+
+	// FailWriteOnCall, if greater than 0, makes that call to Write()
+	// (the first call is 1) fail with WriteErr. Later calls work as
+	// normal.
+	FailWriteOnCall int
+
+	// OneByteReads forces every call to Read() to return (at most)
+	// a single byte.
+	OneByteReads bool
+
+	// ReadDelay is how long to wait before every call to Read().
+	ReadDelay time.Duration
+
+	// WriteDelay is how long to wait before every call to Write().
+	WriteDelay time.Duration
+}
+
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// readErr returns the error to use for injected read faults.
+func (o FaultOptions) readErr() error {
+	if o.ReadErr != nil {
+		return o.ReadErr
+	}
+
+	return ErrInjectedFault
+}
+
+// writeErr returns the error to use for injected write faults.
+func (o FaultOptions) writeErr() error {
+	if o.WriteErr != nil {
+		return o.WriteErr
+	}
+
+	return ErrInjectedFault
+}
@@ -0,0 +1,227 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// FaultyReadWriteCloser wraps any io.ReadWriteCloser, and injects the
+// faults described by its FaultOptions: short reads and writes,
+// mid-stream errors, slow I/O and failing Close() calls.
+//
+// Use it with NewTextIOWrapper() to test how your code copes when the
+// underlying I/O goes wrong.
+//
+// It is safe for concurrent use, if the wrapped io.ReadWriteCloser is.
+type FaultyReadWriteCloser struct {
+	rwc  io.ReadWriteCloser
+	opts FaultOptions
+
+	// readMu guards the read counters, and writeMu guards the write
+	// counters. Neither is held while we call the wrapped stream, so a
+	// Read() that blocks does not stop a Write() from going through.
+	readMu       sync.Mutex
+	readCalls    int
+	bytesRead    int64
+	writeMu      sync.Mutex
+	writeCalls   int
+	bytesWritten int64
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewFaultyReadWriteCloser creates a FaultyReadWriteCloser that injects
+// the given faults into any reads, writes and closes of `rwc`.
+func NewFaultyReadWriteCloser(rwc io.ReadWriteCloser, opts FaultOptions) *FaultyReadWriteCloser {
+	retval := FaultyReadWriteCloser{
+		rwc:  rwc,
+		opts: opts,
+	}
+
+	// all done
+	return &retval
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+// Read reads from the wrapped io.ReadWriteCloser, injecting any read
+// faults that we have been configured with.
+func (d *FaultyReadWriteCloser) Read(p []byte) (int, error) {
+	if d.opts.ReadDelay > 0 {
+		time.Sleep(d.opts.ReadDelay)
+	}
+
+	p, err := d.startRead(p)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := d.rwc.Read(p)
+	d.finishRead(len(p), n)
+
+	return n, err
+}
+
+// startRead counts this call, and works out how much of `p` we are
+// allowed to read into. It reserves those bytes against the
+// FailReadAfterBytes budget, so that concurrent reads cannot overrun it.
+func (d *FaultyReadWriteCloser) startRead(p []byte) ([]byte, error) {
+	d.readMu.Lock()
+	defer d.readMu.Unlock()
+
+	d.readCalls++
+	if d.readCalls == d.opts.FailReadOnCall {
+		return nil, d.opts.readErr()
+	}
+
+	if d.opts.FailReadAfterBytes > 0 {
+		remaining := d.opts.FailReadAfterBytes - d.bytesRead
+		if remaining <= 0 {
+			return nil, d.opts.readErr()
+		}
+		if int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+
+	if d.opts.OneByteReads && len(p) > 1 {
+		p = p[:1]
+	}
+
+	d.bytesRead += int64(len(p))
+	return p, nil
+}
+
+// finishRead gives back any reserved bytes that the wrapped Read()
+// did not use.
+func (d *FaultyReadWriteCloser) finishRead(reserved, n int) {
+	d.readMu.Lock()
+	defer d.readMu.Unlock()
+
+	d.bytesRead -= int64(reserved - n)
+}
+
+// ================================================================
+//
+// io.Writer interface
+//
+// ----------------------------------------------------------------
+
+// Write writes to the wrapped io.ReadWriteCloser, injecting any write
+// faults that we have been configured with.
+func (d *FaultyReadWriteCloser) Write(p []byte) (int, error) {
+	if d.opts.WriteDelay > 0 {
+		time.Sleep(d.opts.WriteDelay)
+	}
+
+	p, short, err := d.startWrite(p)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := d.rwc.Write(p)
+	d.finishWrite(len(p), n)
+	if err == nil && short {
+		err = d.opts.writeErr()
+	}
+
+	return n, err
+}
+
+// startWrite counts this call, and works out how much of `p` we are
+// allowed to write. `short` is true if we have cut `p` down to fit the
+// FailWriteAfterBytes budget. Like startRead(), it reserves the bytes
+// up front.
+func (d *FaultyReadWriteCloser) startWrite(p []byte) ([]byte, bool, error) {
+	d.writeMu.Lock()
+	defer d.writeMu.Unlock()
+
+	d.writeCalls++
+	if d.writeCalls == d.opts.FailWriteOnCall {
+		return nil, false, d.opts.writeErr()
+	}
+
+	short := false
+	if d.opts.FailWriteAfterBytes > 0 {
+		remaining := d.opts.FailWriteAfterBytes - d.bytesWritten
+		if remaining <= 0 {
+			return nil, false, d.opts.writeErr()
+		}
+		if int64(len(p)) > remaining {
+			p = p[:remaining]
+			short = true
+		}
+	}
+
+	d.bytesWritten += int64(len(p))
+	return p, short, nil
+}
+
+// finishWrite gives back any reserved bytes that the wrapped Write()
+// did not use.
+func (d *FaultyReadWriteCloser) finishWrite(reserved, n int) {
+	d.writeMu.Lock()
+	defer d.writeMu.Unlock()
+
+	d.bytesWritten -= int64(reserved - n)
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+// Close closes the wrapped io.ReadWriteCloser. It returns
+// FaultOptions.CloseErr if that has been set.
+func (d *FaultyReadWriteCloser) Close() error {
+	err := d.rwc.Close()
+	if d.opts.CloseErr != nil {
+		return d.opts.CloseErr
+	}
+
+	return err
+}
@@ -0,0 +1,414 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFaultyBuffer is a helper. It returns a FaultyReadWriteCloser that
+// wraps a bytes.Buffer containing the given data.
+func newFaultyBuffer(data string, opts FaultOptions) (*FaultyReadWriteCloser, *bytes.Buffer) {
+	buf := bytes.NewBufferString(data)
+	return NewFaultyReadWriteCloser(NopReadWriteCloser(buf), opts), buf
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+func TestNewFaultyReadWriteCloserWorks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit, _ := newFaultyBuffer("", FaultOptions{})
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, unit)
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+func TestFaultyReadWriteCloserWithNoFaultsPassesReadsThrough(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, _ := newFaultyBuffer("hello world\n", FaultOptions{})
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := io.ReadAll(unit)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world\n", string(actualResult))
+}
+
+func TestFaultyReadWriteCloserCanForceOneByteReads(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, _ := newFaultyBuffer("hello", FaultOptions{OneByteReads: true})
+	buf := make([]byte, 10)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.Read(buf)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, actualLen)
+	assert.Equal(t, "h", string(buf[:actualLen]))
+}
+
+func TestFaultyReadWriteCloserCanFailReadsAfterNBytes(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, _ := newFaultyBuffer("hello world\n", FaultOptions{FailReadAfterBytes: 5})
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := io.ReadAll(unit)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, ErrInjectedFault)
+	assert.Equal(t, "hello", string(actualResult))
+}
+
+func TestFaultyReadWriteCloserCanFailTheNthRead(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedErr := errors.New("read failed")
+	unit, _ := newFaultyBuffer("hello", FaultOptions{
+		FailReadOnCall: 2,
+		ReadErr:        expectedErr,
+		OneByteReads:   true,
+	})
+	buf := make([]byte, 10)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err1 := unit.Read(buf)
+	_, err2 := unit.Read(buf)
+	n3, err3 := unit.Read(buf)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Equal(t, expectedErr, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, "e", string(buf[:n3]))
+}
+
+func TestFaultyReadWriteCloserCanDelayReads(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, _ := newFaultyBuffer("hello", FaultOptions{ReadDelay: 20 * time.Millisecond})
+	start := time.Now()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Read(make([]byte, 10))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+// ================================================================
+//
+// io.Writer interface
+//
+// ----------------------------------------------------------------
+
+func TestFaultyReadWriteCloserCanMakeShortWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, buf := newFaultyBuffer("", FaultOptions{FailWriteAfterBytes: 5})
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	n1, err1 := unit.Write([]byte("hello world"))
+	n2, err2 := unit.Write([]byte("again"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, 5, n1)
+	assert.ErrorIs(t, err1, ErrInjectedFault)
+	assert.Equal(t, 0, n2)
+	assert.ErrorIs(t, err2, ErrInjectedFault)
+	assert.Equal(t, "hello", buf.String())
+}
+
+func TestFaultyReadWriteCloserCanFailTheNthWrite(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit, buf := newFaultyBuffer("", FaultOptions{FailWriteOnCall: 2})
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err1 := unit.Write([]byte("one "))
+	_, err2 := unit.Write([]byte("two "))
+	_, err3 := unit.Write([]byte("three"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.ErrorIs(t, err2, ErrInjectedFault)
+	assert.Nil(t, err3)
+	assert.Equal(t, "one three", buf.String())
+}
+
+func TestFaultyReadWriteCloserDoesNotBlockWritesWhileAReadIsWaiting(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	pr, pw := io.Pipe()
+	buf := new(bytes.Buffer)
+	rwc := struct {
+		io.Reader
+		io.Writer
+		io.Closer
+	}{pr, buf, pr}
+	unit := NewFaultyReadWriteCloser(rwc, FaultOptions{FailWriteAfterBytes: 100})
+
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		unit.Read(make([]byte, 10))
+	}()
+
+	// give the reader time to block inside the wrapped Read()
+	time.Sleep(10 * time.Millisecond)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	writeDone := make(chan error, 1)
+	go func() {
+		_, err := unit.Write([]byte("hello"))
+		writeDone <- err
+	}()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	select {
+	case err := <-writeDone:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Error("Write() blocked behind a waiting Read()")
+	}
+	assert.Equal(t, "hello", buf.String())
+
+	pw.Close()
+	<-readDone
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+func TestFaultyReadWriteCloserCanFailOnClose(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedErr := errors.New("close failed")
+	unit, _ := newFaultyBuffer("", FaultOptions{CloseErr: expectedErr})
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedErr, err)
+}
+
+// ================================================================
+//
+// TextIOWrapper integration
+//
+// ----------------------------------------------------------------
+
+func TestFaultyReadWriteCloserMakesReadLineFail(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rwc, _ := newFaultyBuffer("hello\nworld\n", FaultOptions{FailReadAfterBytes: 8})
+	unit := NewTextIOWrapper(rwc)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	line1, err1 := unit.ReadLine()
+	_, err2 := unit.ReadLine()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Equal(t, "hello\n", line1)
+	assert.ErrorIs(t, err2, ErrInjectedFault)
+}
+
+func TestFaultyReadWriteCloserSupportsReadLineWithOneByteReads(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rwc, _ := newFaultyBuffer("hello\nworld\n", FaultOptions{OneByteReads: true})
+	unit := NewTextIOWrapper(rwc)
+	expectedResult := []string{"hello", "world"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := []string{}
+	for line := range unit.ReadLines() {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestFaultyReadWriteCloserMakesParseIntFail(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rwc, _ := newFaultyBuffer("12345\n", FaultOptions{FailReadOnCall: 1})
+	unit := NewTextIOWrapper(rwc)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := ParseInt(unit)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, ErrInjectedFault)
+}
+
+func TestFaultyReadWriteCloserMakesStringPanic(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rwc, _ := newFaultyBuffer("hello world\n", FaultOptions{FailReadAfterBytes: 4})
+	unit := NewTextIOWrapper(rwc)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.PanicsWithValue(t, ErrInjectedFault, func() {
+		String(unit)
+	})
+}
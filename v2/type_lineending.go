@@ -0,0 +1,189 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"bytes"
+)
+
+// LineEnding decides what ReadLine(), ReadLines(), Strings() and friends
+// do with the end-of-line characters in the input source.
+type LineEnding int
+
+const (
+	// LineEndingDefault is the original behaviour of this package:
+	// ReadLine() keeps the line terminator, while ReadLines() and
+	// Strings() strip both "\n" and "\r\n".
+	LineEndingDefault LineEnding = iota
+
+	// KeepLineEnding keeps the "\n" (or "\r\n") at the end of each line.
+	KeepLineEnding
+
+	// StripLF removes the "\n" at the end of each line. Any "\r" before
+	// the "\n" is kept.
+	StripLF
+
+	// StripCRLF removes the "\n" or "\r\n" at the end of each line.
+	StripCRLF
+
+	// AcceptCR removes the "\n" or "\r\n" at the end of each line, and
+	// also treats a lone "\r" (eg, from classic Mac OS) as the end of a
+	// line.
+	AcceptCR
+)
+
+// DefaultLineTerminator is what WriteLine() adds to the end of each
+// line, unless you have set a different line terminator.
+const DefaultLineTerminator = "\n"
+
+// lineTerminatorOrDefault returns the given line terminator, or
+// DefaultLineTerminator if none has been set.
+func lineTerminatorOrDefault(terminator string) string {
+	if terminator == "" {
+		return DefaultLineTerminator
+	}
+
+	return terminator
+}
+
+// SplitFunc returns a bufio.SplitFunc that splits the input into lines,
+// according to this line ending policy.
+func (e LineEnding) SplitFunc() bufio.SplitFunc {
+	switch e {
+	case KeepLineEnding:
+		return scanLinesKeepEnding
+	case StripLF:
+		return scanLinesStripLF
+	case AcceptCR:
+		return scanLinesAcceptCR
+	default:
+		return bufio.ScanLines
+	}
+}
+
+// iteratorEnding returns the LineEnding that gives ReadLineWithEnding()
+// the same results as our SplitFunc().
+func (e LineEnding) iteratorEnding() LineEnding {
+	if e == LineEndingDefault {
+		return StripCRLF
+	}
+
+	return e
+}
+
+// strip removes the line terminator from the end of the given line,
+// according to this line ending policy.
+func (e LineEnding) strip(line string) string {
+	switch e {
+	case StripLF:
+		return trimSuffixOnce(line, "\n")
+	case StripCRLF, AcceptCR:
+		line = trimSuffixOnce(line, "\n")
+		return trimSuffixOnce(line, "\r")
+	default:
+		return line
+	}
+}
+
+// trimSuffixOnce removes suffix from the end of s, if it is there.
+func trimSuffixOnce(s string, suffix string) string {
+	if len(s) >= len(suffix) && s[len(s)-len(suffix):] == suffix {
+		return s[:len(s)-len(suffix)]
+	}
+
+	return s
+}
+
+// scanLinesKeepEnding is a bufio.SplitFunc that returns each line of
+// text, including its line terminator.
+func scanLinesKeepEnding(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i+1], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	// request more data
+	return 0, nil, nil
+}
+
+// scanLinesStripLF is a bufio.SplitFunc that returns each line of text,
+// with its trailing "\n" removed.
+func scanLinesStripLF(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := scanLinesKeepEnding(data, atEOF)
+	if len(token) > 0 && token[len(token)-1] == '\n' {
+		token = token[:len(token)-1]
+	}
+
+	return advance, token, err
+}
+
+// scanLinesAcceptCR is a bufio.SplitFunc that returns each line of text,
+// with its trailing "\n", "\r\n" or "\r" removed.
+func scanLinesAcceptCR(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+
+		// we have found a "\r" ... is it part of a "\r\n"?
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+
+		// we need to see the next byte before we can decide
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	// request more data
+	return 0, nil, nil
+}
@@ -0,0 +1,151 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lineEndingTestData mixes UNIX, Windows and classic Mac OS line endings
+const lineEndingTestData = "unix\nwindows\r\nmac\rlast"
+
+func TestLineEndingSplitFuncFollowsThePolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ending         LineEnding
+		expectedResult []string
+	}{
+		{LineEndingDefault, []string{"unix", "windows", "mac\rlast"}},
+		{KeepLineEnding, []string{"unix\n", "windows\r\n", "mac\rlast"}},
+		{StripLF, []string{"unix", "windows\r", "mac\rlast"}},
+		{StripCRLF, []string{"unix", "windows", "mac\rlast"}},
+		{AcceptCR, []string{"unix", "windows", "mac", "last"}},
+	}
+
+	for _, testCase := range testCases {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		reader := strings.NewReader(lineEndingTestData)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := collectStrings(NewTextScannerWithOptions(
+			context.Background(),
+			reader,
+			testCase.ending.SplitFunc(),
+			ScanOptions{},
+		))
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedResult, actualResult)
+	}
+}
+
+func TestReadLineWithEndingFollowsThePolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ending         LineEnding
+		expectedResult []string
+	}{
+		{LineEndingDefault, []string{"unix\n", "windows\r\n", "mac\rlast"}},
+		{KeepLineEnding, []string{"unix\n", "windows\r\n", "mac\rlast"}},
+		{StripLF, []string{"unix", "windows\r", "mac\rlast"}},
+		{StripCRLF, []string{"unix", "windows", "mac\rlast"}},
+		{AcceptCR, []string{"unix", "windows", "mac", "last"}},
+	}
+
+	for _, testCase := range testCases {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		reader := strings.NewReader(lineEndingTestData)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		var actualResult []string
+		for {
+			line, err := ReadLineWithEnding(reader, testCase.ending)
+			if line != "" {
+				actualResult = append(actualResult, line)
+			}
+			if err == io.EOF {
+				break
+			}
+			assert.Nil(t, err)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testCase.expectedResult, actualResult)
+	}
+}
+
+func TestReadLineWithEndingUsesTheBufferOfBufferedReaders(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	buf := bytes.NewBufferString("hello world\r\nhave a nice day\r\n")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualFirst, err1 := ReadLineWithEnding(buf, StripCRLF)
+	actualSecond, err2 := ReadLineWithEnding(buf, StripCRLF)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, "hello world", actualFirst)
+	assert.Equal(t, "have a nice day", actualSecond)
+}
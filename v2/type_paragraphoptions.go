@@ -0,0 +1,171 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+)
+
+// ParagraphOptions controls how ReadParagraphs() and friends split text
+// into paragraphs (blocks of lines, separated by blank lines).
+//
+// The zero value splits on empty lines, and drops them.
+type ParagraphOptions struct {
+	// WhitespaceSeparators treats lines that only contain whitespace as
+	// blank lines, as well as lines that are empty.
+	WhitespaceSeparators bool
+
+	// KeepSeparators adds the blank lines after each paragraph to the
+	// end of that paragraph, instead of dropping them. Any blank lines
+	// at the start of the text are added to the start of the first
+	// paragraph.
+	//
+	// Use it if you need to put the text back together exactly as it
+	// was.
+	KeepSeparators bool
+}
+
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// isSeparator returns true if the given line (without its "\n") is a
+// blank line.
+func (o ParagraphOptions) isSeparator(line []byte) bool {
+	if o.WhitespaceSeparators {
+		return len(bytes.TrimSpace(line)) == 0
+	}
+
+	return len(dropCR(line)) == 0
+}
+
+// trimSeparators removes any blank lines, and the final line ending,
+// from the end of the given data.
+func (o ParagraphOptions) trimSeparators(data []byte) []byte {
+	for len(data) > 0 {
+		data = dropLineEnding(data)
+		i := bytes.LastIndexByte(data, '\n')
+		if !o.isSeparator(data[i+1:]) {
+			return data
+		}
+		data = data[:i+1]
+	}
+
+	return data
+}
+
+// scan is a bufio.SplitFunc that returns each paragraph of text, as
+// controlled by our options.
+func (o ParagraphOptions) scan(data []byte, atEOF bool) (int, []byte, error) {
+	// skip over any blank lines in front of the paragraph
+	start := 0
+	for {
+		i := bytes.IndexByte(data[start:], '\n')
+		if i < 0 || !o.isSeparator(data[start:start+i]) {
+			break
+		}
+		start += i + 1
+	}
+
+	// look for the blank line that ends the paragraph
+	pos := start
+	for {
+		i := bytes.IndexByte(data[pos:], '\n')
+		if i < 0 {
+			break
+		}
+		lineEnd := pos + i + 1
+
+		j := bytes.IndexByte(data[lineEnd:], '\n')
+		if j < 0 {
+			break
+		}
+		if o.isSeparator(data[lineEnd : lineEnd+j]) {
+			if o.KeepSeparators {
+				return o.scanSeparators(data, lineEnd+j+1, atEOF)
+			}
+			return lineEnd + j + 1, dropLineEnding(data[start:lineEnd]), nil
+		}
+
+		pos = lineEnd
+	}
+
+	if atEOF {
+		if len(o.trimSeparators(data[start:])) == 0 {
+			return len(data), nil, nil
+		}
+		if o.KeepSeparators {
+			return len(data), data, nil
+		}
+		return len(data), o.trimSeparators(data[start:]), nil
+	}
+
+	// request more data
+	if o.KeepSeparators {
+		return 0, nil, nil
+	}
+	return start, nil, nil
+}
+
+// scanSeparators returns the paragraph that ends at `end`, along with
+// all of the blank lines that follow it.
+func (o ParagraphOptions) scanSeparators(data []byte, end int, atEOF bool) (int, []byte, error) {
+	for {
+		i := bytes.IndexByte(data[end:], '\n')
+		if i >= 0 {
+			if !o.isSeparator(data[end : end+i]) {
+				break
+			}
+			end += i + 1
+			continue
+		}
+
+		// we are looking at an incomplete line
+		rest := data[end:]
+		if len(rest) > 0 && !o.isSeparator(rest) {
+			break
+		}
+		if !atEOF {
+			// we cannot tell where the blank lines stop yet
+			return 0, nil, nil
+		}
+		end = len(data)
+		break
+	}
+
+	return end, data[:end], nil
+}
@@ -0,0 +1,155 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParagraphOptionsZeroValueOnlySplitsOnEmptyLines(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "one\n  \ntwo\n\nthree\n"
+	expectedResult := []string{"one\n  \ntwo", "three"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, NewParagraphSplitFunc(ParagraphOptions{}))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestParagraphOptionsWhitespaceSeparatorsSplitsOnWhitespaceOnlyLines(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := " \t\none  \n  \ntwo\n\t\r\n\nthree\n  "
+	opts := ParagraphOptions{WhitespaceSeparators: true}
+	expectedResult := []string{"one  ", "two", "three"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, NewParagraphSplitFunc(opts))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestParagraphOptionsKeepSeparatorsKeepsEveryByte(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "\n\nFrom: alice\nTo: bob\n\nbody line\r\n\r\n\n"
+	opts := ParagraphOptions{KeepSeparators: true}
+	expectedResult := []string{
+		"\n\nFrom: alice\nTo: bob\n\n",
+		"body line\r\n\r\n\n",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, NewParagraphSplitFunc(opts))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Equal(t, testData, strings.Join(actualResult, ""))
+}
+
+func TestParagraphOptionsKeepSeparatorsWorksWithWhitespaceSeparators(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "one\n \ntwo\nthree\n\t\n  "
+	opts := ParagraphOptions{
+		WhitespaceSeparators: true,
+		KeepSeparators:       true,
+	}
+	expectedResult := []string{"one\n \n", "two\nthree\n\t\n  "}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, NewParagraphSplitFunc(opts))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestParagraphOptionsReturnNothingForBlankInput(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "\n \n\t\n"
+	opts := ParagraphOptions{
+		WhitespaceSeparators: true,
+		KeepSeparators:       true,
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, NewParagraphSplitFunc(opts))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Empty(t, actualResult)
+}
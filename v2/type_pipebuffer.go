@@ -0,0 +1,141 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"sync"
+)
+
+// pipeBuffer carries data in one direction between two pipe endpoints.
+//
+// It is safe for concurrent use.
+type pipeBuffer struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// data holds everything that has been written, but not yet read
+	data []byte
+
+	// limit is the most that `data` may hold, or 0 for unlimited
+	limit int
+
+	// writerClosed is set when the writing endpoint has been closed
+	writerClosed bool
+
+	// readerClosed is set when the reading endpoint has been closed
+	readerClosed bool
+}
+
+// newPipeBuffer creates a pipeBuffer that holds up to `limit` bytes.
+func newPipeBuffer(limit int) *pipeBuffer {
+	retval := pipeBuffer{limit: limit}
+	retval.cond = sync.NewCond(&retval.mu)
+
+	// all done
+	return &retval
+}
+
+// Read blocks until there is data to read. It returns io.EOF once the
+// writer has been closed, and all of the data has been read.
+func (d *pipeBuffer) Read(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for len(d.data) == 0 && !d.writerClosed && !d.readerClosed {
+		d.cond.Wait()
+	}
+
+	if d.readerClosed {
+		return 0, io.ErrClosedPipe
+	}
+	if len(d.data) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, d.data)
+	d.data = d.data[n:]
+	d.cond.Broadcast()
+
+	return n, nil
+}
+
+// Write adds the given data to our buffer, blocking whenever the buffer
+// is full.
+func (d *pipeBuffer) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	written := 0
+	for written < len(p) {
+		for d.limit > 0 && len(d.data) >= d.limit && !d.writerClosed && !d.readerClosed {
+			d.cond.Wait()
+		}
+
+		if d.writerClosed || d.readerClosed {
+			return written, io.ErrClosedPipe
+		}
+
+		chunk := p[written:]
+		if d.limit > 0 && len(chunk) > d.limit-len(d.data) {
+			chunk = chunk[:d.limit-len(d.data)]
+		}
+
+		d.data = append(d.data, chunk...)
+		written += len(chunk)
+		d.cond.Broadcast()
+	}
+
+	return written, nil
+}
+
+// closeWriter tells the reader that no more data is coming.
+func (d *pipeBuffer) closeWriter() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.writerClosed = true
+	d.cond.Broadcast()
+}
+
+// closeReader tells the writer that nobody is listening any more.
+func (d *pipeBuffer) closeReader() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.readerClosed = true
+	d.data = nil
+	d.cond.Broadcast()
+}
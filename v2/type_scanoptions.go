@@ -0,0 +1,197 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"unicode/utf8"
+)
+
+// TooLongPolicy tells a text scanner what to do when it finds a token
+// (eg, a line or a word) that is larger than ScanOptions.MaxTokenSize.
+type TooLongPolicy int
+
+const (
+	// TooLongError stops the scan, and reports bufio.ErrTooLong. This
+	// is the default, and matches the behaviour of bufio.Scanner.
+	TooLongError TooLongPolicy = iota
+
+	// TooLongTruncate returns the first MaxTokenSize bytes of the token,
+	// and throws the rest of the token away.
+	TooLongTruncate
+
+	// TooLongSplit returns the token in chunks of (up to) MaxTokenSize
+	// bytes.
+	TooLongSplit
+)
+
+// ScanOptions controls the buffering of a text scanner.
+//
+// The zero value gives you the same buffer sizes as bufio.Scanner.
+type ScanOptions struct {
+	// InitialBufferSize is how many bytes the scanner's buffer starts
+	// with. The buffer grows as needed, up to MaxTokenSize.
+	//
+	// Set it to 0 to use the bufio.Scanner default.
+	InitialBufferSize int
+
+	// MaxTokenSize is the largest token (including any terminator, such
+	// as the trailing '\n' on a line) that the scanner will buffer.
+	//
+	// Set it to 0 to use bufio.MaxScanTokenSize.
+	MaxTokenSize int
+
+	// TooLong decides what happens to any token that is larger than
+	// MaxTokenSize.
+	TooLong TooLongPolicy
+}
+
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// maxTokenSize returns the effective maximum token size.
+func (o ScanOptions) maxTokenSize() int {
+	if o.MaxTokenSize > 0 {
+		return o.MaxTokenSize
+	}
+
+	return bufio.MaxScanTokenSize
+}
+
+// applyTo configures the given scanner to use our options.
+func (o ScanOptions) applyTo(scanner *bufio.Scanner, splitter bufio.SplitFunc) {
+	// do we need to change the scanner's buffer?
+	if o.InitialBufferSize > 0 || o.MaxTokenSize > 0 {
+		maxSize := o.maxTokenSize()
+		initialSize := o.InitialBufferSize
+		if initialSize <= 0 || initialSize > maxSize {
+			initialSize = maxSize
+			if initialSize > 4096 {
+				initialSize = 4096
+			}
+		}
+
+		scanner.Buffer(make([]byte, 0, initialSize), maxSize)
+	}
+
+	switch o.TooLong {
+	case TooLongTruncate, TooLongSplit:
+		scanner.Split(o.wrapSplitter(splitter))
+	default:
+		scanner.Split(splitter)
+	}
+}
+
+// wrapSplitter returns a bufio.SplitFunc that implements our TooLong
+// policy on top of the given bufio.SplitFunc.
+func (o ScanOptions) wrapSplitter(splitter bufio.SplitFunc) bufio.SplitFunc {
+	maxSize := o.maxTokenSize()
+
+	// are we throwing away the rest of a truncated token?
+	skipping := false
+
+	// did we just split a token at the maximum size?
+	split := false
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := splitter(data, atEOF)
+		if err != nil {
+			return advance, token, err
+		}
+
+		if split && (advance > 0 || token != nil) {
+			split = false
+
+			// a terminator straight after the split point ends the
+			// token that we have already returned; it is not an empty
+			// token of its own
+			if token != nil && len(token) == 0 {
+				return advance, nil, nil
+			}
+		}
+
+		if skipping {
+			// have we reached the end of the token yet?
+			if token != nil {
+				skipping = false
+				return advance, nil, nil
+			}
+			if advance == 0 && len(data) >= maxSize {
+				return len(data), nil, nil
+			}
+			return advance, nil, nil
+		}
+
+		// is the scanner's buffer full?
+		if advance > 0 || token != nil || atEOF || len(data) < maxSize {
+			return advance, token, err
+		}
+
+		// if we get here, bufio.Scanner is about to give up with
+		// bufio.ErrTooLong ... unless we return some of the data now
+		//
+		// we avoid cutting a UTF-8 character in half, where we can
+		cut := maxSize
+		start := lastRuneStart(data[:cut])
+		if start > 0 && !utf8.FullRune(data[start:cut]) {
+			cut = start
+		}
+
+		if o.TooLong == TooLongTruncate {
+			skipping = true
+			return len(data), data[:cut], nil
+		}
+
+		split = true
+		return cut, data[:cut], nil
+	}
+}
+
+// lastRuneStart returns the index of the first byte of the last UTF-8
+// character in b.
+func lastRuneStart(b []byte) int {
+	i := len(b) - 1
+	for i > 0 && i > len(b)-utf8.UTFMax && !utf8.RuneStart(b[i]) {
+		i--
+	}
+	if i < 0 {
+		return 0
+	}
+
+	return i
+}
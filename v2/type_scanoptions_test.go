@@ -0,0 +1,237 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Buffer sizes
+//
+// ----------------------------------------------------------------
+
+func TestScanOptionsMaxTokenSizeAllowsLinesLongerThanTheDefault(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	longLine := strings.Repeat("x", bufio.MaxScanTokenSize*2)
+	reader := strings.NewReader(longLine + "\nhello world\n")
+	opts := ScanOptions{MaxTokenSize: bufio.MaxScanTokenSize * 4}
+
+	expectedResult := []string{longLine, "hello world"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := collectStrings(
+		ReadLinesWithOptions(context.Background(), reader, opts),
+	)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+// ================================================================
+//
+// TooLong policies
+//
+// ----------------------------------------------------------------
+
+func TestScanOptionsTooLongErrorReportsErrTooLong(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("hello\nhave a nice day\nbye\n")
+	opts := ScanOptions{MaxTokenSize: 8, TooLong: TooLongError}
+
+	expectedResult := []string{"hello"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := collectStrings(
+		ReadLinesWithOptions(context.Background(), reader, opts),
+	)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, errors.Is(err, bufio.ErrTooLong))
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestScanOptionsTooLongTruncateDropsTheRestOfTheLine(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("hello\nhave a nice day\nbye\n")
+	opts := ScanOptions{MaxTokenSize: 8, TooLong: TooLongTruncate}
+
+	expectedResult := []string{"hello", "have a n", "bye"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := collectStrings(
+		ReadLinesWithOptions(context.Background(), reader, opts),
+	)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestScanOptionsTooLongSplitReturnsTheLineInChunks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("hello\nhave a nice day\nbye\n")
+	opts := ScanOptions{MaxTokenSize: 8, TooLong: TooLongSplit}
+
+	expectedResult := []string{"hello", "have a n", "ice day", "bye"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := collectStrings(
+		ReadLinesWithOptions(context.Background(), reader, opts),
+	)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestScanOptionsTooLongSplitDoesNotAddAnEmptyTokenAtTheMaxSize(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// the second and fifth lines are exactly MaxTokenSize bytes (or a
+	// multiple of it) long, and the fourth line really is empty
+	reader := strings.NewReader("ab\nxxxxxxxx\ncd\n\nxxxxxxxxyyyyyyyy\nef\n")
+	opts := ScanOptions{MaxTokenSize: 8, TooLong: TooLongSplit}
+
+	expectedResult := []string{"ab", "xxxxxxxx", "cd", "", "xxxxxxxx", "yyyyyyyy", "ef"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := collectStrings(
+		ReadLinesWithOptions(context.Background(), reader, opts),
+	)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestScanOptionsTooLongSplitDoesNotCutUTF8CharactersInHalf(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// the smiley is 4 bytes long, and would normally straddle the
+	// 8-byte chunk boundary
+	reader := strings.NewReader("hello 🙂 world\n")
+	opts := ScanOptions{MaxTokenSize: 8, TooLong: TooLongSplit}
+
+	expectedResult := []string{"hello ", "🙂 wor", "ld"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := collectStrings(
+		ReadLinesWithOptions(context.Background(), reader, opts),
+	)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestScanOptionsTooLongSplitWorksWithReadWords(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("hello abcdefghijkl world")
+	opts := ScanOptions{MaxTokenSize: 8, TooLong: TooLongSplit}
+
+	expectedResult := []string{"hello", "abcdefgh", "ijkl", "world"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := collectStrings(
+		ReadWordsWithOptions(context.Background(), reader, opts),
+	)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
@@ -0,0 +1,204 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"sync"
+)
+
+// SyncTextReaderWriter adds a mutex to any TextReaderWriter, so that it
+// is safe for concurrent use.
+//
+// Every method holds the mutex while it calls the wrapped
+// TextReaderWriter. ReadLines() and ReadWords() only hold it during each
+// read from the wrapped TextReaderWriter, so that other goroutines can
+// still write while you `range` over the channel.
+type SyncTextReaderWriter struct {
+	mu sync.Mutex
+	rw TextReaderWriter
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewSyncTextReaderWriter wraps the given TextReaderWriter, so that it is
+// safe for concurrent use.
+//
+// Once wrapped, you must only use the TextReaderWriter through the
+// SyncTextReaderWriter.
+func NewSyncTextReaderWriter(rw TextReaderWriter) *SyncTextReaderWriter {
+	retval := SyncTextReaderWriter{rw: rw}
+
+	// all done
+	return &retval
+}
+
+// NewSyncTextBuffer creates a new TextBuffer that is safe for concurrent
+// use (eg, as a log sink shared by several goroutines).
+func NewSyncTextBuffer() *SyncTextReaderWriter {
+	return NewSyncTextReaderWriter(NewTextBuffer())
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+// Read reads up to len(p) bytes from the wrapped TextReaderWriter.
+func (d *SyncTextReaderWriter) Read(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.Read(p)
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+// Close closes the wrapped TextReaderWriter, if it supports io.Closer.
+// Otherwise, it does nothing.
+func (d *SyncTextReaderWriter) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	closer, ok := d.rw.(io.Closer)
+	if !ok {
+		return nil
+	}
+
+	return closer.Close()
+}
+
+// ================================================================
+//
+// TextReader interface
+//
+// ----------------------------------------------------------------
+
+// ParseInt returns the next line from the wrapped TextReaderWriter as an
+// integer.
+func (d *SyncTextReaderWriter) ParseInt() (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.ParseInt()
+}
+
+// ReadLine returns the next line from the wrapped TextReaderWriter.
+func (d *SyncTextReaderWriter) ReadLine() (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.ReadLine()
+}
+
+// ReadLines returns a channel that you can `range` over to get each
+// remaining line from the wrapped TextReaderWriter.
+func (d *SyncTextReaderWriter) ReadLines() <-chan string {
+	return ReadLines(d)
+}
+
+// ReadWords returns a channel that you can `range` over to get each
+// remaining word from the wrapped TextReaderWriter.
+func (d *SyncTextReaderWriter) ReadWords() <-chan string {
+	return ReadWords(d)
+}
+
+// String returns all the remaining data in the wrapped TextReaderWriter
+// as a single string.
+func (d *SyncTextReaderWriter) String() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.String()
+}
+
+// Strings returns all of the remaining data in the wrapped
+// TextReaderWriter as an array of strings, one line per array entry.
+func (d *SyncTextReaderWriter) Strings() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.Strings()
+}
+
+// TrimmedString returns all of the remaining data in the wrapped
+// TextReaderWriter as a string, with any leading or trailing whitespace
+// removed.
+func (d *SyncTextReaderWriter) TrimmedString() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.TrimmedString()
+}
+
+// ================================================================
+//
+// TextWriter interface
+//
+// ----------------------------------------------------------------
+
+// Write writes len(p) bytes to the wrapped TextReaderWriter.
+func (d *SyncTextReaderWriter) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.Write(p)
+}
+
+// WriteRune writes a single rune (a unicode character) to the wrapped
+// TextReaderWriter.
+func (d *SyncTextReaderWriter) WriteRune(r rune) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.WriteRune(r)
+}
+
+// WriteString writes a string to the wrapped TextReaderWriter.
+func (d *SyncTextReaderWriter) WriteString(s string) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.rw.WriteString(s)
+}
@@ -0,0 +1,191 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// these tests are only meaningful when run with `go test -race`
+
+// ================================================================
+//
+// Interface compatibility
+//
+// ----------------------------------------------------------------
+
+func TestSyncTextReaderWriterImplementsTextReaderWriter(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// this will not compile if our SyncTextReaderWriter is not
+	// compatible with the TextReader and TextWriter interfaces
+	tmp := interfaceCompatibility{
+		in:  NewSyncTextBuffer(),
+		out: NewSyncTextBuffer(),
+	}
+	assert.NotNil(t, tmp)
+
+	unit := NewSyncTextBuffer()
+	var i interface{} = unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(TextReaderWriter)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
+// ================================================================
+//
+// Concurrency
+//
+// ----------------------------------------------------------------
+
+func TestSyncTextBufferSupportsConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewSyncTextBuffer()
+
+	var expectedResult []string
+	for i := 0; i < 100; i++ {
+		expectedResult = append(expectedResult, strconv.Itoa(i))
+	}
+	sort.Strings(expectedResult)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			unit.WriteString(strconv.Itoa(i) + "\n")
+		}(i)
+	}
+	wg.Wait()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	actualResult := unit.Strings()
+	sort.Strings(actualResult)
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestSyncTextBufferSupportsConcurrentReadsAndWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewSyncTextBuffer()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			unit.WriteString("hello world\n")
+		}
+	}()
+
+	actualCount := 0
+	go func() {
+		defer wg.Done()
+		for actualCount < 100 {
+			_, err := unit.ReadLine()
+			if err == nil {
+				actualCount++
+			}
+		}
+	}()
+	wg.Wait()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, 100, actualCount)
+}
+
+func TestSyncTextReaderWriterReadLinesDoesNotBlockWriters(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewSyncTextBuffer()
+	unit.WriteString("hello world\nhave a nice day\n")
+
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for line := range unit.ReadLines() {
+		actualResult = append(actualResult, line)
+
+		// this would deadlock if ReadLines() held the lock
+		unit.WriteRune('!')
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	// our writes may (or may not) be read back as an extra line,
+	// depending on how quickly the scanner reaches the end of the
+	// buffer
+	assert.Equal(t, expectedResult, actualResult[:2])
+}
@@ -0,0 +1,133 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+)
+
+// TeeReadWriteCloser wraps any io.ReadWriteCloser, and mirrors every
+// byte that is read from it, and every byte that is written to it, to
+// separate io.Writers.
+//
+// Use it to see exactly what traffic is passing through an interactive
+// protocol.
+type TeeReadWriteCloser struct {
+	rwc io.ReadWriteCloser
+
+	// readTo receives a copy of everything that we read
+	readTo io.Writer
+
+	// writeTo receives a copy of everything that we write
+	writeTo io.Writer
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewTeeReadWriteCloser creates a TeeReadWriteCloser that copies
+// everything read from `rwc` to `readTo`, and everything written to
+// `rwc` to `writeTo`.
+//
+// Either io.Writer can be nil, if you are not interested in that
+// direction.
+func NewTeeReadWriteCloser(rwc io.ReadWriteCloser, readTo io.Writer, writeTo io.Writer) *TeeReadWriteCloser {
+	retval := TeeReadWriteCloser{
+		rwc:     rwc,
+		readTo:  readTo,
+		writeTo: writeTo,
+	}
+
+	// all done
+	return &retval
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+// Read reads from the wrapped io.ReadWriteCloser, and copies whatever
+// we read to our `readTo` io.Writer.
+//
+// If we cannot copy the data, we return the error from the copy (unless
+// the read itself failed).
+func (d *TeeReadWriteCloser) Read(p []byte) (int, error) {
+	n, err := d.rwc.Read(p)
+	if n > 0 && d.readTo != nil {
+		if _, teeErr := d.readTo.Write(p[:n]); teeErr != nil && err == nil {
+			return n, teeErr
+		}
+	}
+
+	return n, err
+}
+
+// ================================================================
+//
+// io.Writer interface
+//
+// ----------------------------------------------------------------
+
+// Write writes to the wrapped io.ReadWriteCloser, and copies whatever
+// was written to our `writeTo` io.Writer.
+//
+// If we cannot copy the data, we return the error from the copy (unless
+// the write itself failed).
+func (d *TeeReadWriteCloser) Write(p []byte) (int, error) {
+	n, err := d.rwc.Write(p)
+	if n > 0 && d.writeTo != nil {
+		if _, teeErr := d.writeTo.Write(p[:n]); teeErr != nil && err == nil {
+			return n, teeErr
+		}
+	}
+
+	return n, err
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+// Close closes the wrapped io.ReadWriteCloser.
+func (d *TeeReadWriteCloser) Close() error {
+	return d.rwc.Close()
+}
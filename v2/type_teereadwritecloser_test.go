@@ -0,0 +1,216 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+func TestNewTeeReadWriteCloserWorks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit := NewTeeReadWriteCloser(NewDevNull(), nil, nil)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, unit)
+}
+
+// ================================================================
+//
+// Mirroring
+//
+// ----------------------------------------------------------------
+
+func TestTeeReadWriteCloserMirrorsReadsAndWrites(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	buf := bytes.NewBufferString("hello world\n")
+	reads := new(bytes.Buffer)
+	writes := new(bytes.Buffer)
+	unit := NewTeeReadWriteCloser(NopReadWriteCloser(buf), reads, writes)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	readResult, readErr := io.ReadAll(unit)
+	_, writeErr := unit.Write([]byte("goodbye\n"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, readErr)
+	assert.Nil(t, writeErr)
+	assert.Equal(t, "hello world\n", string(readResult))
+	assert.Equal(t, "hello world\n", reads.String())
+	assert.Equal(t, "goodbye\n", writes.String())
+	assert.Equal(t, "goodbye\n", buf.String())
+}
+
+func TestTeeReadWriteCloserReportsMirrorErrors(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedErr := errors.New("mirror failed")
+	failing := newFailingReadWriteCloser("", expectedErr)
+	unit := NewTeeReadWriteCloser(NopReadWriteCloser(new(bytes.Buffer)), nil, failing)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.Write([]byte("hello"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestTeeReadWriteCloserPassesCloseThrough(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	devNull := NewDevNull()
+	unit := NewTeeReadWriteCloser(devNull, nil, nil)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.Close()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	_, err := devNull.Write([]byte("hello"))
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
+
+// ================================================================
+//
+// TextIOWrapper integration
+//
+// ----------------------------------------------------------------
+
+func TestNewTeeTextIOWrapperMirrorsTraffic(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	buf := bytes.NewBufferString("hello world\n")
+	reads := new(strings.Builder)
+	writes := new(strings.Builder)
+	unit := NewTeeTextIOWrapper(NopReadWriteCloser(buf), reads, writes)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	line, err := unit.ReadLine()
+	unit.WriteLine("goodbye")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world\n", line)
+	assert.Equal(t, "hello world\n", reads.String())
+	assert.Equal(t, "goodbye\n", writes.String())
+}
+
+func TestNewRecordingTextIOWrapperRecordsATranscript(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	buf := bytes.NewBufferString("250 OK\n")
+	unit, transcript := NewRecordingTextIOWrapper(NopReadWriteCloser(buf))
+
+	// this will not compile if our recording wrapper is not compatible
+	// with the TextReaderWriter interface
+	var rw TextReaderWriter = unit
+	assert.NotNil(t, rw)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit.ReadLine()
+	unit.WriteLine("HELO example.com")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	entries := transcript.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, DirectionRead, entries[0].Direction)
+	assert.Equal(t, "250 OK\n", string(entries[0].Data))
+	assert.Equal(t, DirectionWrite, entries[1].Direction)
+	assert.Equal(t, "HELO example.com\n", string(entries[1].Data))
+	assert.False(t, entries[1].Time.Before(entries[0].Time))
+
+	assert.Equal(t, "HELO example.com\n", transcript.Writes())
+	assert.Equal(t, "250 OK\n", transcript.Reads())
+
+	lines := strings.Split(strings.TrimSpace(transcript.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[0], ` < "250 OK\n"`))
+	assert.True(t, strings.HasSuffix(lines[1], ` > "HELO example.com\n"`))
+}
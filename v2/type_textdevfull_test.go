@@ -0,0 +1,120 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Interface compatibility
+//
+// ----------------------------------------------------------------
+
+func TestTextDevicesImplementTextReaderAndTextWriter(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// this will not compile if our text devices are not compatible
+	// with the TextReader and TextWriter interfaces
+	testData := []interfaceCompatibility{
+		{in: NewTextDevFull(), out: NewTextDevFull()},
+		{in: NewTextDevRandom(42), out: NewTextDevRandom(42)},
+		{in: NewTextDevURandom(), out: NewTextDevURandom()},
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Len(t, testData, 3)
+}
+
+// ================================================================
+//
+// TextWriter interface
+//
+// ----------------------------------------------------------------
+
+func TestTextDevFullWriteStringReturnsENOSPC(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextDevFull()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.WriteString("hello world\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, syscall.ENOSPC)
+}
+
+func TestTextDevRandomReadIsRepeatableForTheSameSeed(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit1 := NewTextDevRandom(42)
+	unit2 := NewTextDevRandom(42)
+
+	expectedOutput := make([]byte, 32)
+	actualOutput := make([]byte, 32)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit1.Read(expectedOutput)
+	unit2.Read(actualOutput)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedOutput, actualOutput)
+}
@@ -48,7 +48,7 @@ type TextDevNull struct {
 // NewTextDevNull creates an emulation of /dev/null that also supports
 // the TextReader / TextWriter interfaces.
 func NewTextDevNull() *TextDevNull {
-	retval := TextDevNull{TextIOWrapper{NewDevNull()}}
+	retval := TextDevNull{TextIOWrapper{ReadWriteCloser: NewDevNull()}}
 
 	// all done
 	return &retval
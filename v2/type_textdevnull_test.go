@@ -390,3 +390,26 @@ func TestTextDevNullWriteStringDoesNotFail(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedLen, actualLen)
 }
+
+func TestTextDevNullWriteLinesDoesNotFail(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := []string{"hello world", "have a nice day"}
+	unit := NewTextDevNull()
+
+	expectedLen := len("hello world\nhave a nice day\n")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.WriteLines(testData)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedLen, actualLen)
+}
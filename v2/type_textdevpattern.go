@@ -0,0 +1,69 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+// TextDevPattern is a DevPattern with full TextReader / TextWriter
+// support.
+type TextDevPattern struct {
+	TextIOWrapper
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewTextDevPattern creates a DevPattern that repeats the given pattern,
+// and that also supports the TextReader / TextWriter interfaces.
+//
+// It returns io.EOF after `length` bytes have been read. Set `length` to
+// UnlimitedLength to read forever.
+func NewTextDevPattern(pattern []byte, length int64) *TextDevPattern {
+	retval := TextDevPattern{TextIOWrapper{ReadWriteCloser: NewDevPattern(pattern, length)}}
+
+	// all done
+	return &retval
+}
+
+// NewTextDevPatternLine creates a DevPattern that repeats the given line
+// of text, and that also supports the TextReader / TextWriter interfaces.
+// We add a trailing "\n" if the line does not already have one.
+func NewTextDevPatternLine(line string, length int64) *TextDevPattern {
+	retval := TextDevPattern{TextIOWrapper{ReadWriteCloser: NewDevPatternLine(line, length)}}
+
+	// all done
+	return &retval
+}
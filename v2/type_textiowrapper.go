@@ -35,13 +35,52 @@
 package ioextra
 
 import (
+	"bufio"
+	"context"
+	"fmt"
 	"io"
+	"iter"
+	"slices"
+	"strconv"
 )
 
 // TextIOWrapper adds TextReader / TextWriter support to anything that
 // supports io.ReadWriteCloser.
 type TextIOWrapper struct {
 	io.ReadWriteCloser
+
+	// reader buffers everything that we read from the underlying
+	// io.ReadWriteCloser, so that no data is lost between calls
+	reader *bufio.Reader
+
+	// scanOptions controls the bufio.Scanner used by ReadLines() and
+	// ReadWords()
+	scanOptions ScanOptions
+
+	// lineEnding controls what our read methods do with the end of
+	// each line
+	lineEnding LineEnding
+
+	// lineTerminator is what WriteLine() adds to the end of each line
+	lineTerminator string
+
+	// encoding is the character encoding of the underlying data
+	encoding Encoding
+
+	// strictEncoding tells us to report invalid data, instead of
+	// replacing it
+	strictEncoding bool
+
+	// utf8Policy tells us what to do with invalid UTF-8
+	utf8Policy UTF8Policy
+
+	// decoder converts the underlying data into UTF-8, when we are not
+	// using EncodingDefault and UTF8PassThrough
+	decoder *decodingReader
+
+	// encoder converts what we write into our encoding, when we are
+	// not using EncodingDefault and UTF8PassThrough
+	encoder *encodingWriter
 }
 
 // ================================================================
@@ -53,18 +92,276 @@ type TextIOWrapper struct {
 // NewTextIOWrapper wraps your io.ReadWriteCloser with full support
 // for the TextReader / TextWriter interfaces.
 func NewTextIOWrapper(i io.ReadWriteCloser) *TextIOWrapper {
-	retval := TextIOWrapper{i}
+	retval := TextIOWrapper{ReadWriteCloser: i}
 
 	// all done
 	return &retval
 }
 
+// NewTeeTextIOWrapper wraps your io.ReadWriteCloser with full support
+// for the TextReader / TextWriter interfaces. Every byte read from it
+// is copied to `readTo`, and every byte written to it is copied to
+// `writeTo`. Either io.Writer can be nil.
+//
+// Our read methods buffer ahead, so `readTo` sees data when it is read
+// from your io.ReadWriteCloser, not when you consume it.
+func NewTeeTextIOWrapper(i io.ReadWriteCloser, readTo io.Writer, writeTo io.Writer) *TextIOWrapper {
+	return NewTextIOWrapper(NewTeeReadWriteCloser(i, readTo, writeTo))
+}
+
+// NewRecordingTextIOWrapper wraps your io.ReadWriteCloser with full
+// support for the TextReader / TextWriter interfaces, and records all
+// of the traffic in the returned Transcript.
+func NewRecordingTextIOWrapper(i io.ReadWriteCloser) (*TextIOWrapper, *Transcript) {
+	transcript := NewTranscript()
+	retval := NewTeeTextIOWrapper(
+		i,
+		transcript.Writer(DirectionRead),
+		transcript.Writer(DirectionWrite),
+	)
+
+	// all done
+	return retval, transcript
+}
+
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// bufferedReader returns the buffered reader that sits between us and
+// the underlying io.Reader, creating it if necessary.
+//
+// All of our read methods must go through this, otherwise we will skip
+// over any data that it has already buffered.
+func (d *TextIOWrapper) bufferedReader() *bufio.Reader {
+	if d.reader == nil {
+		d.reader = bufio.NewReader(d.textSource())
+	}
+
+	return d.reader
+}
+
+// isPlainUTF8 returns true if we pass data through untouched, without
+// any decoding, encoding or checking.
+func (d *TextIOWrapper) isPlainUTF8() bool {
+	return d.encoding == EncodingDefault && d.utf8Policy == UTF8PassThrough
+}
+
+// textSource returns where our buffered reader gets its data from: the
+// underlying io.Reader, or a decoder that sits in front of it.
+func (d *TextIOWrapper) textSource() io.Reader {
+	if d.isPlainUTF8() {
+		return d.ReadWriteCloser
+	}
+
+	if d.decoder == nil {
+		d.decoder = newDecodingReader(d.ReadWriteCloser, d.encoding, d.strictEncoding, d.utf8Policy)
+	}
+
+	return d.decoder
+}
+
+// textSink returns where our write methods send their data: the
+// underlying io.Writer, or an encoder that sits in front of it.
+func (d *TextIOWrapper) textSink() io.Writer {
+	if d.isPlainUTF8() {
+		return d.ReadWriteCloser
+	}
+
+	if d.encoder == nil {
+		d.encoder = newEncodingWriter(d.ReadWriteCloser, d.writeEncoding(), d.strictEncoding, d.utf8Policy)
+	}
+
+	return d.encoder
+}
+
+// writeEncoding returns the encoding that our write methods use
+func (d *TextIOWrapper) writeEncoding() Encoding {
+	if d.encoding == EncodingAuto {
+		return EncodingUTF8
+	}
+
+	return d.encoding
+}
+
+// ================================================================
+//
+// Settings
+//
+// ----------------------------------------------------------------
+
+// SetScanOptions sets the buffer sizes, and the policy for tokens that
+// are too long, used by ReadLines(), ReadWords() and Strings().
+func (d *TextIOWrapper) SetScanOptions(opts ScanOptions) {
+	d.scanOptions = opts
+}
+
+// SetLineEnding sets what ReadLine(), ReadLines(), Lines() and Strings()
+// do with the end of each line.
+func (d *TextIOWrapper) SetLineEnding(ending LineEnding) {
+	d.lineEnding = ending
+}
+
+// SetLineTerminator sets what WriteLine() adds to the end of each line.
+// Use an empty string to go back to DefaultLineTerminator.
+func (d *TextIOWrapper) SetLineTerminator(terminator string) {
+	d.lineTerminator = terminator
+}
+
+// SetEncoding sets the character encoding of the underlying data. Our
+// read methods decode it into UTF-8, and our write methods encode UTF-8
+// back into it.
+//
+// Call it before you read or write anything. Any data that has already
+// been buffered is thrown away.
+func (d *TextIOWrapper) SetEncoding(encoding Encoding) {
+	d.encoding = encoding
+	d.resetEncoding()
+}
+
+// SetStrictEncoding sets whether or not our read and write methods
+// return an EncodingError when they find data that is invalid in our
+// encoding. When it is not set, invalid data is replaced with the
+// unicode replacement character (or '?', if the encoding does not
+// support that).
+//
+// Call it before you read or write anything. Any data that has already
+// been buffered is thrown away.
+func (d *TextIOWrapper) SetStrictEncoding(strict bool) {
+	d.strictEncoding = strict
+	d.resetEncoding()
+}
+
+// SetUTF8Policy sets what our read and write methods do when they find
+// invalid UTF-8: pass it through untouched (the default), replace it, or
+// return an EncodingError.
+//
+// Call it before you read or write anything. Any data that has already
+// been buffered is thrown away.
+func (d *TextIOWrapper) SetUTF8Policy(policy UTF8Policy) {
+	d.utf8Policy = policy
+	d.resetEncoding()
+}
+
+// flushEncoder writes out anything that our encoder is holding on to
+// (an incomplete UTF-8 character, from the end of the last write).
+func (d *TextIOWrapper) flushEncoder() error {
+	if d.encoder == nil {
+		return nil
+	}
+
+	return d.encoder.Flush()
+}
+
+// resetEncoding makes sure that our next read or write uses our
+// current encoding settings
+func (d *TextIOWrapper) resetEncoding() {
+	d.reader = nil
+	d.decoder = nil
+	d.encoder = nil
+}
+
+// ================================================================
+//
+// io.Reader interface
+//
+// ----------------------------------------------------------------
+
+// Read reads up to len(p) bytes from our underlying io.Reader. Any data
+// that has already been buffered by our other read methods is returned
+// first.
+func (d *TextIOWrapper) Read(p []byte) (int, error) {
+	return d.bufferedReader().Read(p)
+}
+
+// ================================================================
+//
+// io.Closer interface
+//
+// ----------------------------------------------------------------
+
+// Close closes the underlying io.Closer, and discards any data that
+// we have buffered but not yet returned.
+//
+// If the last write ended part-way through a UTF-8 character, that
+// partial character is written out first, according to the policies set
+// by SetStrictEncoding() and SetUTF8Policy(). Close returns the
+// EncodingError if those policies reject it.
+func (d *TextIOWrapper) Close() error {
+	err := d.flushEncoder()
+	d.resetEncoding()
+	closeErr := d.ReadWriteCloser.Close()
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+// ================================================================
+//
+// io.Writer interface
+//
+// ----------------------------------------------------------------
+
+// Write writes len(p) bytes to our underlying io.Writer, encoding them
+// first if you have called SetEncoding().
+func (d *TextIOWrapper) Write(p []byte) (int, error) {
+	return d.textSink().Write(p)
+}
+
 // ================================================================
 //
 // TextReader
 //
 // ----------------------------------------------------------------
 
+// Floats returns an iterator that you can `range` over to get each
+// remaining whitespace-separated number from our underlying io.Reader,
+// as a float64. Tokens that are not numbers are returned with a
+// *TokenError.
+func (d *TextIOWrapper) Floats() iter.Seq2[float64, error] {
+	return parseTokens(d.WordsWithErrors(), parseFloat64)
+}
+
+// Ints returns an iterator that you can `range` over to get each
+// remaining whitespace-separated number from our underlying io.Reader,
+// as an int. Tokens that are not integers are returned with a
+// *TokenError.
+func (d *TextIOWrapper) Ints() iter.Seq2[int, error] {
+	return parseTokens(d.WordsWithErrors(), strconv.Atoi)
+}
+
+// Lines returns an iterator that you can `range` over to get each
+// remaining line from our underlying io.Reader. It stops at the first
+// read error.
+func (d *TextIOWrapper) Lines() iter.Seq[string] {
+	return withoutErrors(d.LinesWithErrors())
+}
+
+// LinesWithErrors returns an iterator that you can `range` over to get
+// each remaining line from our underlying io.Reader, and any read error.
+func (d *TextIOWrapper) LinesWithErrors() iter.Seq2[string, error] {
+	return newReadIterator(func() (string, error) {
+		return ReadLineWithEnding(d.bufferedReader(), d.lineEnding.iteratorEnding())
+	})
+}
+
+// ParseBool returns the next line in our underlying io.Reader as a bool.
+//
+// See ParseBool() for the values that it accepts.
+func (d *TextIOWrapper) ParseBool() (bool, error) {
+	return ParseBool(d)
+}
+
+// ParseFloat returns the next line in our underlying io.Reader as a
+// float64.
+func (d *TextIOWrapper) ParseFloat() (float64, error) {
+	return ParseFloat(d)
+}
+
 // ParseInt returns the next line in our underlying io.Reader as an
 // integer.
 //
@@ -74,21 +371,204 @@ func (d *TextIOWrapper) ParseInt() (int, error) {
 	return ParseInt(d)
 }
 
+// ParseInt64 returns the next line in our underlying io.Reader as a
+// base 10 int64.
+func (d *TextIOWrapper) ParseInt64() (int64, error) {
+	return ParseInt64(d)
+}
+
+// ParseIntBase returns the next line in our underlying io.Reader as an
+// int64 in the given base.
+//
+// Use base 0 to support 0x, 0o and 0b prefixes, and underscores between
+// digits.
+func (d *TextIOWrapper) ParseIntBase(base int) (int64, error) {
+	return ParseIntBase(d, base)
+}
+
+// ParseUint returns the next line in our underlying io.Reader as a base
+// 10 uint64.
+func (d *TextIOWrapper) ParseUint() (uint64, error) {
+	return ParseUint(d)
+}
+
+// ParseUintBase returns the next line in our underlying io.Reader as a
+// uint64 in the given base.
+//
+// Use base 0 to support 0x, 0o and 0b prefixes, and underscores between
+// digits.
+func (d *TextIOWrapper) ParseUintBase(base int) (uint64, error) {
+	return ParseUintBase(d, base)
+}
+
+// ReadAllString returns all of the remaining data in our underlying
+// io.Reader as a single string. Unlike String(), it returns any read
+// error instead of panicking.
+func (d *TextIOWrapper) ReadAllString() (string, error) {
+	return ReadAllString(d)
+}
+
+// ReadAllStrings returns all of the remaining data in our underlying
+// io.Reader as an array of strings, one line per array entry. Unlike
+// Strings(), it also returns any read error.
+func (d *TextIOWrapper) ReadAllStrings() ([]string, error) {
+	return collectStrings(d.ReadLinesWithOptions(context.Background(), d.scanOptions))
+}
+
+// ReadAllTrimmed returns all of the remaining data in our underlying
+// io.Reader as a string, with any leading or trailing whitespace
+// removed. Unlike TrimmedString(), it returns any read error instead of
+// panicking.
+func (d *TextIOWrapper) ReadAllTrimmed() (string, error) {
+	return ReadAllTrimmed(d)
+}
+
+// ReadDelimited returns a channel that you can `range` over to get each
+// remaining `sep`-separated record from our underlying io.Reader. The
+// separator is not included in the records.
+func (d *TextIOWrapper) ReadDelimited(sep string) <-chan string {
+	return d.ReadRecords(NewDelimiterSplitFunc(sep))
+}
+
+// ReadFloats returns a channel that you can `range` over to get each
+// remaining whitespace-separated number from our underlying io.Reader,
+// as a float64. Tokens that are not numbers are sent with a
+// *TokenError.
+func (d *TextIOWrapper) ReadFloats() <-chan FloatResult {
+	chn, _ := d.ReadFloatsContext(context.Background())
+	return chn
+}
+
+// ReadFloatsContext works just like ReadFloats(), but stops when the given
+// context is cancelled. See ReadFloatsContext() for details.
+func (d *TextIOWrapper) ReadFloatsContext(ctx context.Context) (<-chan FloatResult, func() error) {
+	return floatResults(ctx, d.Floats())
+}
+
+// ReadInts returns a channel that you can `range` over to get each
+// remaining whitespace-separated number from our underlying io.Reader,
+// as an int. Tokens that are not integers are sent with a *TokenError.
+func (d *TextIOWrapper) ReadInts() <-chan IntResult {
+	chn, _ := d.ReadIntsContext(context.Background())
+	return chn
+}
+
+// ReadIntsContext works just like ReadInts(), but stops when the given
+// context is cancelled. See ReadIntsContext() for details.
+func (d *TextIOWrapper) ReadIntsContext(ctx context.Context) (<-chan IntResult, func() error) {
+	return intResults(ctx, d.Ints())
+}
+
 // ReadLine returns the next line from our underlying io.Reader.
 func (d *TextIOWrapper) ReadLine() (string, error) {
-	return ReadLine(d)
+	return ReadLineWithEnding(d.bufferedReader(), d.lineEnding)
 }
 
 // ReadLines returns a channel that you can `range` over to get each
 // remaining line from our underlying io.Reader.
 func (d *TextIOWrapper) ReadLines() <-chan string {
-	return ReadLines(d)
+	chn, _ := d.ReadLinesWithOptions(context.Background(), d.scanOptions)
+	return chn
+}
+
+// ReadLinesContext returns a channel that you can `range` over to get
+// each remaining line from our underlying io.Reader, until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextIOWrapper) ReadLinesContext(ctx context.Context) (<-chan string, func() error) {
+	return d.ReadLinesWithOptions(ctx, d.scanOptions)
+}
+
+// ReadLinesWithOptions works just like ReadLinesContext(), but uses the
+// given ScanOptions instead of the ones set by SetScanOptions().
+func (d *TextIOWrapper) ReadLinesWithOptions(ctx context.Context, opts ScanOptions) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, d, d.lineEnding.SplitFunc(), opts)
+}
+
+// ReadParagraphs returns a channel that you can `range` over to get
+// each remaining paragraph from our underlying io.Reader. Paragraphs
+// are blocks of lines, separated by blank lines.
+//
+// Each paragraph is sent as an array of lines, without their line
+// endings. Use ParagraphOptions to decide which lines count as blank,
+// and whether or not they are kept.
+func (d *TextIOWrapper) ReadParagraphs(opts ParagraphOptions) <-chan []string {
+	chn, _ := d.ReadParagraphsContext(context.Background(), opts)
+	return chn
+}
+
+// ReadParagraphsContext works just like ReadParagraphs(), until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextIOWrapper) ReadParagraphsContext(ctx context.Context, opts ParagraphOptions) (<-chan []string, func() error) {
+	return ReadParagraphsWithOptions(ctx, d, opts, d.scanOptions)
+}
+
+// ReadParagraphsJoined works just like ReadParagraphs(), but sends each
+// paragraph as a single string. The lines of the paragraph are joined by
+// their original line endings.
+func (d *TextIOWrapper) ReadParagraphsJoined(opts ParagraphOptions) <-chan string {
+	return d.ReadRecords(NewParagraphSplitFunc(opts))
+}
+
+// ReadRecords returns a channel that you can `range` over to get each
+// remaining record from our underlying io.Reader, as found by the given
+// bufio.SplitFunc (eg, ScanNUL, ScanParagraphs or bufio.ScanRunes).
+func (d *TextIOWrapper) ReadRecords(split bufio.SplitFunc) <-chan string {
+	chn, _ := d.ReadRecordsContext(context.Background(), split)
+	return chn
+}
+
+// ReadRecordsContext works just like ReadRecords(), until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextIOWrapper) ReadRecordsContext(ctx context.Context, split bufio.SplitFunc) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, d, split, d.scanOptions)
 }
 
 // ReadWords returns a channel that you can `range` over to get each
 // remaining word from our underlying io.Reader.
 func (d *TextIOWrapper) ReadWords() <-chan string {
-	return ReadWords(d)
+	chn, _ := ReadWordsWithOptions(context.Background(), d, d.scanOptions)
+	return chn
+}
+
+// ReadWordsContext returns a channel that you can `range` over to get
+// each remaining word from our underlying io.Reader, until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func (d *TextIOWrapper) ReadWordsContext(ctx context.Context) (<-chan string, func() error) {
+	return ReadWordsWithOptions(ctx, d, d.scanOptions)
+}
+
+// ReadWordsWithOptions works just like ReadWordsContext(), but uses the
+// given ScanOptions instead of the ones set by SetScanOptions().
+func (d *TextIOWrapper) ReadWordsWithOptions(ctx context.Context, opts ScanOptions) (<-chan string, func() error) {
+	return ReadWordsWithOptions(ctx, d, opts)
+}
+
+// ScanLine reads the next line from our underlying io.Reader, and
+// decodes its whitespace-separated fields into the given destinations.
+//
+// See ScanLine() for the supported destinations.
+func (d *TextIOWrapper) ScanLine(dest ...interface{}) error {
+	return ScanLine(d, dest...)
+}
+
+// ScanLineDelimited reads the next line from our underlying io.Reader,
+// and decodes its sep-separated fields into the given destinations.
+//
+// See ScanLine() for the supported destinations.
+func (d *TextIOWrapper) ScanLineDelimited(sep string, dest ...interface{}) error {
+	return ScanLineDelimited(d, sep, dest...)
 }
 
 // String returns all the remaining data in our underlying io.Reader
@@ -110,16 +590,128 @@ func (d *TextIOWrapper) TrimmedString() string {
 	return TrimmedString(d)
 }
 
+// Words returns an iterator that you can `range` over to get each
+// remaining word from our underlying io.Reader. It stops at the first
+// read error.
+func (d *TextIOWrapper) Words() iter.Seq[string] {
+	return withoutErrors(d.WordsWithErrors())
+}
+
+// WordsWithErrors returns an iterator that you can `range` over to get
+// each remaining word from our underlying io.Reader, and any read error.
+func (d *TextIOWrapper) WordsWithErrors() iter.Seq2[string, error] {
+	return newReadIterator(func() (string, error) {
+		return readWord(d.bufferedReader())
+	})
+}
+
+// ================================================================
+//
+// TextPeeker interface
+//
+// ----------------------------------------------------------------
+
+// PeekLine returns the next line from our underlying io.Reader, without
+// consuming it. It returns the same line that ReadLine() would.
+//
+// PeekLine can only look as far ahead as our read buffer (4096 bytes).
+// If the line is longer than that, it returns as much of the line as it
+// can, and bufio.ErrBufferFull.
+func (d *TextIOWrapper) PeekLine() (string, error) {
+	return peekLineWithEnding(d.bufferedReader(), d.lineEnding)
+}
+
+// PeekRune returns the next character from our underlying io.Reader,
+// and its size in bytes, without consuming it.
+func (d *TextIOWrapper) PeekRune() (rune, int, error) {
+	return peekRune(d.bufferedReader())
+}
+
+// ReadRune returns the next character from our underlying io.Reader,
+// and its size in bytes.
+func (d *TextIOWrapper) ReadRune() (rune, int, error) {
+	return d.bufferedReader().ReadRune()
+}
+
+// UnreadRune puts the last character returned by ReadRune() back. It must
+// be called straight after ReadRune().
+func (d *TextIOWrapper) UnreadRune() error {
+	return d.bufferedReader().UnreadRune()
+}
+
 // ================================================================
 //
 // TextWriter
 //
 // ----------------------------------------------------------------
 
+// Printf writes formatted text to our underlying io.Writer, just like
+// fmt.Fprintf() does. It returns the number of bytes written, and any
+// error encountered that caused the write to fail.
+func (d *TextIOWrapper) Printf(format string, a ...interface{}) (int, error) {
+	return fmt.Fprintf(d, format, a...)
+}
+
+// Println writes its operands to our underlying io.Writer, separated by
+// spaces and followed by the line terminator set by SetLineTerminator().
+// It returns the number of bytes written, and any error encountered that
+// caused the write to fail.
+func (d *TextIOWrapper) Println(a ...interface{}) (int, error) {
+	line := fmt.Sprintln(a...)
+	return d.WriteLine(line[:len(line)-1])
+}
+
+// WriteLine writes the given line to our underlying io.Writer, followed by
+// the line terminator set by SetLineTerminator(). It returns the number of
+// bytes written, and any error encountered that caused the write to fail.
+func (d *TextIOWrapper) WriteLine(line string) (int, error) {
+	return d.WriteString(line + lineTerminatorOrDefault(d.lineTerminator))
+}
+
+// WriteLines writes each of the given lines to our underlying
+// io.Writer, each followed by the line terminator set by
+// SetLineTerminator().
+//
+// It returns the total number of bytes written, and stops at the first
+// error encountered.
+func (d *TextIOWrapper) WriteLines(lines []string) (int, error) {
+	return d.WriteLinesSeq(slices.Values(lines))
+}
+
+// WriteLinesFrom writes each line received from the given channel to
+// our underlying io.Writer, each followed by the line terminator set by
+// SetLineTerminator(), until the channel is closed.
+//
+// It returns the total number of bytes written, and the first error
+// encountered. See WriteLinesFrom() for what happens after an error.
+func (d *TextIOWrapper) WriteLinesFrom(lines <-chan string) (int, error) {
+	return d.WriteLinesSeq(drainingSeq(lines))
+}
+
+// WriteLinesSeq writes each line from the given iterator to our
+// underlying io.Writer, each followed by the line terminator set by
+// SetLineTerminator().
+//
+// It returns the total number of bytes written, and stops at the first
+// error encountered.
+func (d *TextIOWrapper) WriteLinesSeq(lines iter.Seq[string]) (int, error) {
+	return writeLines(d, lines, lineTerminatorOrDefault(d.lineTerminator))
+}
+
 // WriteRune writes a single rune (a unicode character) to the underlying
 // io.Writer. It returns the number of types written, and any error
 // encountered that caused the write to fail.
+//
+// Invalid runes are written as the unicode replacement character, U+FFFD,
+// unless you have set UTF8Error or strict encoding, when an EncodingError
+// is returned.
 func (d *TextIOWrapper) WriteRune(r rune) (int, error) {
+	if encoder, ok := d.textSink().(*encodingWriter); ok {
+		if err := encoder.checkRune(r); err != nil {
+			return 0, err
+		}
+	}
+
 	return WriteRune(d, r)
 }
 
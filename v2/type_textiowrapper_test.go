@@ -0,0 +1,407 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingReadWriteCloser is a helper. It returns the given data, followed
+// by the given error.
+type failingReadWriteCloser struct {
+	io.Reader
+	err error
+}
+
+func newFailingReadWriteCloser(data string, err error) *failingReadWriteCloser {
+	return &failingReadWriteCloser{
+		Reader: strings.NewReader(data),
+		err:    err,
+	}
+}
+
+func (f *failingReadWriteCloser) Read(p []byte) (int, error) {
+	n, err := f.Reader.Read(p)
+	if err == io.EOF {
+		err = f.err
+	}
+	return n, err
+}
+
+func (f *failingReadWriteCloser) Write(p []byte) (int, error) {
+	return 0, f.err
+}
+
+func (f *failingReadWriteCloser) Close() error {
+	return nil
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+func TestNewTextIOWrapperWorks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	unit := NewTextIOWrapper(NopReadWriteCloser(new(bytes.Buffer)))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotNil(t, unit)
+}
+
+// ================================================================
+//
+// Interface compatibility
+//
+// ----------------------------------------------------------------
+
+func TestTextIOWrapperImplementsTextReaderWriter(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextIOWrapper(NopReadWriteCloser(new(bytes.Buffer)))
+	var i interface{} = unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(TextReaderWriter)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
+func TestTextIOWrapperImplementsReadAllReader(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextIOWrapper(NopReadWriteCloser(new(bytes.Buffer)))
+	var i interface{} = unit
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, ok := i.(ReadAllReader)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+}
+
+// ================================================================
+//
+// TextReader interface
+//
+// ----------------------------------------------------------------
+
+func TestTextIOWrapperReadLineDoesNotLoseBufferedData(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextIOWrapper(NopReadWriteCloser(
+		bytes.NewBufferString("hello world\nhave a nice day\n"),
+	))
+
+	expectedFirst := "hello world\n"
+	expectedSecond := "have a nice day\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualFirst, err1 := unit.ReadLine()
+	actualSecond, err2 := unit.ReadLine()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, expectedFirst, actualFirst)
+	assert.Equal(t, expectedSecond, actualSecond)
+}
+
+func TestTextIOWrapperReadLineCanBeMixedWithOtherReadMethods(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextIOWrapper(NopReadWriteCloser(
+		bytes.NewBufferString("100\nhello world\nhave a nice day\n"),
+	))
+
+	expectedInt := 100
+	expectedLine := "hello world\n"
+	expectedWords := []string{"have", "a", "nice", "day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualInt, err1 := unit.ParseInt()
+	actualLine, err2 := unit.ReadLine()
+
+	var actualWords []string
+	for word := range unit.ReadWords() {
+		actualWords = append(actualWords, word)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, expectedInt, actualInt)
+	assert.Equal(t, expectedLine, actualLine)
+	assert.Equal(t, expectedWords, actualWords)
+}
+
+func TestTextIOWrapperReadWordsContextStopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextIOWrapper(NopReadWriteCloser(
+		bytes.NewBufferString(strings.Repeat("hello world\n", 1000)),
+	))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	wordsChn, wordsErr := unit.ReadWordsContext(ctx)
+
+	var actualResult []string
+	for word := range wordsChn {
+		actualResult = append(actualResult, word)
+		cancel()
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	// the scanner may already be waiting to send the next word when
+	// we cancel the context, so we can get one extra word
+	assert.LessOrEqual(t, len(actualResult), 2)
+	assert.Equal(t, context.Canceled, wordsErr())
+}
+
+func TestTextIOWrapperReadAllStringReturnsReadErrors(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedErr := errors.New("connection reset")
+	unit := NewTextIOWrapper(newFailingReadWriteCloser("hello world\n", expectedErr))
+
+	expectedResult := "hello world\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := unit.ReadAllString()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestTextIOWrapperReadAllStringsReturnsReadErrors(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedErr := errors.New("connection reset")
+	unit := NewTextIOWrapper(newFailingReadWriteCloser("hello world\n", expectedErr))
+
+	expectedResult := []string{"hello world"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := unit.ReadAllStrings()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestTextIOWrapperReadAllTrimmedReturnsReadErrors(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedErr := errors.New("connection reset")
+	unit := NewTextIOWrapper(newFailingReadWriteCloser(" hello world\n", expectedErr))
+
+	expectedResult := "hello world"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := unit.ReadAllTrimmed()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestTextIOWrapperReadLineFollowsTheLineEnding(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextIOWrapper(NopReadWriteCloser(
+		bytes.NewBufferString("hello world\r\nhave a nice day\r\n"),
+	))
+	unit.SetLineEnding(StripCRLF)
+
+	expectedFirst := "hello world"
+	expectedRemainder := []string{"have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualFirst, err := unit.ReadLine()
+	actualRemainder := unit.Strings()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedFirst, actualFirst)
+	assert.Equal(t, expectedRemainder, actualRemainder)
+}
+
+// ================================================================
+//
+// TextWriter interface
+//
+// ----------------------------------------------------------------
+
+func TestTextIOWrapperWriteLineUsesTheConfiguredLineTerminator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	dest := new(bytes.Buffer)
+	unit := NewTextIOWrapper(NopReadWriteCloser(dest))
+	unit.SetLineTerminator("\r\n")
+
+	expectedResult := "hello world\r\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.WriteLine("hello world")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, dest.String())
+}
+
+func TestTextIOWrapperWriteLinesFromStopsWritingAtTheFirstError(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedErr := errors.New("broken pipe")
+	unit := NewTextIOWrapper(newFailingReadWriteCloser("", expectedErr))
+
+	lines := make(chan string)
+	go func() {
+		for _, line := range []string{"hello", "world", "have", "a", "nice", "day"} {
+			lines <- line
+		}
+		close(lines)
+	}()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualLen, err := unit.WriteLinesFrom(lines)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	// if WriteLinesFrom() did not drain the channel, our goroutine
+	// would still be blocked at this point
+	_, stillOpen := <-lines
+
+	assert.Equal(t, expectedErr, err)
+	assert.Zero(t, actualLen)
+	assert.False(t, stillOpen)
+}
@@ -0,0 +1,188 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Direction says which way the data in a TranscriptEntry was travelling.
+type Direction int
+
+const (
+	// DirectionRead marks data that was read from the wrapped
+	// io.ReadWriteCloser
+	DirectionRead Direction = iota
+
+	// DirectionWrite marks data that was written to the wrapped
+	// io.ReadWriteCloser
+	DirectionWrite
+)
+
+// String returns the direction marker used by Transcript.String():
+// "<" for reads, and ">" for writes.
+func (d Direction) String() string {
+	if d == DirectionWrite {
+		return ">"
+	}
+
+	return "<"
+}
+
+// TranscriptEntry is a single chunk of data recorded by a Transcript.
+type TranscriptEntry struct {
+	// Time is when the data was recorded
+	Time time.Time
+
+	// Direction says whether the data was read or written
+	Direction Direction
+
+	// Data is a copy of the bytes that were read or written
+	Data []byte
+}
+
+// Transcript is an in-memory record of all of the traffic that passes
+// through a TeeReadWriteCloser, with timestamps and direction markers.
+//
+// It is safe for concurrent use.
+type Transcript struct {
+	mu      sync.Mutex
+	entries []TranscriptEntry
+}
+
+// ================================================================
+//
+// Constructors
+//
+// ----------------------------------------------------------------
+
+// NewTranscript creates an empty Transcript.
+func NewTranscript() *Transcript {
+	retval := Transcript{}
+
+	// all done
+	return &retval
+}
+
+// ================================================================
+//
+// Recording
+//
+// ----------------------------------------------------------------
+
+// transcriptWriter is the io.Writer returned by Transcript.Writer()
+type transcriptWriter struct {
+	transcript *Transcript
+	direction  Direction
+}
+
+func (w transcriptWriter) Write(p []byte) (int, error) {
+	w.transcript.record(w.direction, p)
+	return len(p), nil
+}
+
+// Writer returns an io.Writer that records everything written to it
+// as traffic travelling in the given direction.
+func (d *Transcript) Writer(direction Direction) io.Writer {
+	return transcriptWriter{transcript: d, direction: direction}
+}
+
+// record adds a copy of the given data to our transcript.
+func (d *Transcript) record(direction Direction, p []byte) {
+	entry := TranscriptEntry{
+		Time:      time.Now(),
+		Direction: direction,
+		Data:      append([]byte(nil), p...),
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = append(d.entries, entry)
+}
+
+// ================================================================
+//
+// Reporting
+//
+// ----------------------------------------------------------------
+
+// Entries returns a copy of everything that we have recorded so far,
+// in the order that it was recorded.
+func (d *Transcript) Entries() []TranscriptEntry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]TranscriptEntry(nil), d.entries...)
+}
+
+// Reads returns everything that has been read, joined together.
+func (d *Transcript) Reads() string {
+	return d.joined(DirectionRead)
+}
+
+// Writes returns everything that has been written, joined together.
+func (d *Transcript) Writes() string {
+	return d.joined(DirectionWrite)
+}
+
+// String returns a human-readable copy of the transcript, with one
+// entry per line, eg:
+//
+//	15:04:05.000000 > "HELO example.com\r\n"
+//	15:04:05.000123 < "250 OK\r\n"
+func (d *Transcript) String() string {
+	var sb strings.Builder
+	for _, entry := range d.Entries() {
+		fmt.Fprintf(&sb, "%s %s %q\n", entry.Time.Format("15:04:05.000000"), entry.Direction, entry.Data)
+	}
+
+	return sb.String()
+}
+
+// joined returns all of the data recorded in the given direction.
+func (d *Transcript) joined(direction Direction) string {
+	var sb strings.Builder
+	for _, entry := range d.Entries() {
+		if entry.Direction == direction {
+			sb.Write(entry.Data)
+		}
+	}
+
+	return sb.String()
+}
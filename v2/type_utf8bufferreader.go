@@ -0,0 +1,284 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// utf8BufferReader applies a UTF8Policy to everything that is read from
+// a bytes.Buffer.
+//
+// It looks at the buffer's contents before it reads them, so that it
+// never takes more than one character out of the buffer ahead of time.
+type utf8BufferReader struct {
+	buf    *bytes.Buffer
+	policy UTF8Policy
+
+	// out holds checked bytes that we have taken out of the buffer,
+	// but not yet returned
+	out []byte
+
+	// last is the last byte returned by ReadByte()
+	last byte
+
+	// lastRune holds the bytes of the last character returned by
+	// ReadRune(), until something else is read
+	lastRune []byte
+
+	// offset is how many bytes we have taken out of the buffer
+	offset int64
+}
+
+// newUTF8BufferReader creates a reader that applies the given policy to
+// everything read from `buf`.
+func newUTF8BufferReader(buf *bytes.Buffer, policy UTF8Policy) *utf8BufferReader {
+	retval := utf8BufferReader{
+		buf:    buf,
+		policy: policy,
+	}
+
+	// all done
+	return &retval
+}
+
+// next checks the next character in our buffer. It returns the bytes
+// to use for that character, and how many bytes it takes up in the
+// buffer.
+//
+// Our buffer's contents are treated as complete: an incomplete character
+// at the end of the buffer is invalid.
+func (d *utf8BufferReader) next(data []byte) ([]byte, int, error) {
+	r, size := utf8.DecodeRune(data)
+	if r != utf8.RuneError || size != 1 || d.policy == UTF8PassThrough {
+		return data[:size], size, nil
+	}
+
+	if d.policy == UTF8Error {
+		return nil, 0, &EncodingError{
+			Encoding: EncodingUTF8,
+			Offset:   d.offset,
+			Err:      ErrInvalidByteSequence,
+		}
+	}
+
+	return []byte(string(utf8.RuneError)), 1, nil
+}
+
+// consume takes n bytes out of our buffer.
+func (d *utf8BufferReader) consume(n int) {
+	d.buf.Next(n)
+	d.offset += int64(n)
+}
+
+// Read fills p with checked bytes from our buffer.
+func (d *utf8BufferReader) Read(p []byte) (int, error) {
+	d.lastRune = nil
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+
+	for n < len(p) {
+		data := d.buf.Bytes()
+		if len(data) == 0 {
+			break
+		}
+
+		chunk, size, err := d.next(data)
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if n+len(chunk) > len(p) {
+			break
+		}
+
+		n += copy(p[n:], chunk)
+		d.consume(size)
+	}
+
+	switch {
+	case n > 0 || len(p) == 0:
+		return n, nil
+	case d.buf.Len() == 0:
+		return 0, io.EOF
+	default:
+		return 0, io.ErrShortBuffer
+	}
+}
+
+// fill makes sure that we have at least one checked character waiting
+// in `out`, unless our buffer is empty.
+func (d *utf8BufferReader) fill() error {
+	if len(d.out) > 0 {
+		return nil
+	}
+
+	data := d.buf.Bytes()
+	if len(data) == 0 {
+		return io.EOF
+	}
+
+	chunk, size, err := d.next(data)
+	if err != nil {
+		return err
+	}
+	d.out = append(d.out, chunk...)
+	d.consume(size)
+
+	return nil
+}
+
+// ReadByte returns the next checked byte from our buffer.
+func (d *utf8BufferReader) ReadByte() (byte, error) {
+	d.lastRune = nil
+	if err := d.fill(); err != nil {
+		return 0, err
+	}
+
+	d.last = d.out[0]
+	d.out = d.out[1:]
+
+	return d.last, nil
+}
+
+// UnreadByte puts the last byte returned by ReadByte() back.
+func (d *utf8BufferReader) UnreadByte() error {
+	d.out = append([]byte{d.last}, d.out...)
+	return nil
+}
+
+// ReadString returns checked bytes from our buffer, up to and including
+// the given delimiter.
+func (d *utf8BufferReader) ReadString(delim byte) (string, error) {
+	d.lastRune = nil
+	var retval []byte
+
+	// do we already have the whole string?
+	if i := bytes.IndexByte(d.out, delim); i >= 0 {
+		retval = append(retval, d.out[:i+1]...)
+		d.out = d.out[i+1:]
+		return string(retval), nil
+	}
+	retval = append(retval, d.out...)
+	d.out = nil
+
+	data := d.buf.Bytes()
+	end := bytes.IndexByte(data, delim) + 1
+	found := end > 0
+	if !found {
+		end = len(data)
+	}
+
+	retval, n, err := d.policy.apply(retval, data[:end], true, d.offset)
+	d.consume(n)
+
+	switch {
+	case err != nil:
+		return string(retval), err
+	case !found:
+		return string(retval), io.EOF
+	default:
+		return string(retval), nil
+	}
+}
+
+// ReadRune returns the next checked character from our buffer.
+func (d *utf8BufferReader) ReadRune() (rune, int, error) {
+	d.lastRune = nil
+	if err := d.fill(); err != nil {
+		return 0, 0, err
+	}
+
+	r, size := utf8.DecodeRune(d.out)
+	d.lastRune = append(d.lastRune, d.out[:size]...)
+	d.out = d.out[size:]
+
+	return r, size, nil
+}
+
+// UnreadRune puts the last character returned by ReadRune() back.
+func (d *utf8BufferReader) UnreadRune() error {
+	if d.lastRune == nil {
+		return bufio.ErrInvalidUnreadRune
+	}
+
+	d.out = append(d.lastRune, d.out...)
+	d.lastRune = nil
+
+	return nil
+}
+
+// Peek returns the next n checked bytes, without taking them out of our
+// buffer. Like bufio.Reader.Peek(), it returns fewer bytes and an error
+// if n bytes are not available.
+func (d *utf8BufferReader) Peek(n int) ([]byte, error) {
+	if len(d.out) >= n {
+		return d.out[:n], nil
+	}
+
+	// we only check as much of the buffer as we need to
+	data := d.buf.Bytes()
+	end := min(n, len(data))
+	retval := append([]byte(nil), d.out...)
+	var err error
+	for {
+		retval, _, err = d.policy.apply(retval[:len(d.out)], data[:end], end == len(data), d.offset)
+		if err != nil || len(retval) >= n || end == len(data) {
+			break
+		}
+		end = min(end+utf8.UTFMax, len(data))
+	}
+
+	switch {
+	case len(retval) > n:
+		return retval[:n], nil
+	case err == nil && len(retval) < n:
+		return retval, io.EOF
+	default:
+		return retval, err
+	}
+}
+
+// Buffered returns the most bytes that Peek() can return. Every byte in
+// our buffer is available, and replacing an invalid byte turns it into
+// three bytes.
+func (d *utf8BufferReader) Buffered() int {
+	return len(d.out) + d.buf.Len()*len(string(utf8.RuneError))
+}
@@ -0,0 +1,96 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"unicode/utf8"
+)
+
+// UTF8Policy tells the text wrappers what to do when they find invalid
+// UTF-8, both in the data that they read, and in the data that they are
+// asked to write.
+type UTF8Policy int
+
+const (
+	// UTF8PassThrough leaves invalid UTF-8 untouched. This is the
+	// default.
+	UTF8PassThrough UTF8Policy = iota
+
+	// UTF8Replace replaces each invalid byte with the unicode
+	// replacement character, U+FFFD.
+	UTF8Replace
+
+	// UTF8Error returns an EncodingError, which reports the byte offset
+	// of the first invalid byte.
+	UTF8Error
+)
+
+// apply checks `data` against our policy, adding the result to `out`.
+//
+// It returns the new `out`, and how many bytes of `data` it has dealt
+// with. `final` tells us that no more data is coming, so that any
+// incomplete character at the end of `data` is invalid. `offset` is where
+// data[0] is, for error reporting.
+func (p UTF8Policy) apply(out []byte, data []byte, final bool, offset int64) ([]byte, int, error) {
+	if p == UTF8PassThrough {
+		return append(out, data...), len(data), nil
+	}
+
+	i := 0
+	for i < len(data) {
+		if !final && !utf8.FullRune(data[i:]) {
+			break
+		}
+
+		r, size := utf8.DecodeRune(data[i:])
+		if r == utf8.RuneError && size == 1 {
+			if p == UTF8Error {
+				return out, i, &EncodingError{
+					Encoding: EncodingUTF8,
+					Offset:   offset + int64(i),
+					Err:      ErrInvalidByteSequence,
+				}
+			}
+			out = utf8.AppendRune(out, utf8.RuneError)
+			i++
+			continue
+		}
+
+		out = append(out, data[i:i+size]...)
+		i += size
+	}
+
+	return out, i, nil
+}
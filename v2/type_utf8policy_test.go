@@ -0,0 +1,402 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Read methods
+//
+// ----------------------------------------------------------------
+
+func TestUTF8PassThroughLeavesInvalidDataUntouched(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		input := "caf\xe9\nbad \xff\xfe\n"
+		unit := newUnit(input)
+		unit.SetUTF8Policy(UTF8PassThrough)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := unit.String()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, input, actualResult, name)
+	}
+}
+
+func TestUTF8ReplaceReplacesEachInvalidByte(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("caf\xe9\nbad \xff\xfe words\n")
+		unit.SetUTF8Policy(UTF8Replace)
+		expectedLine := "caf�\n"
+		expectedWords := []string{"bad", "��", "words"}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualLine, err := unit.ReadLine()
+		actualWords := []string{}
+		for word := range unit.ReadWords() {
+			actualWords = append(actualWords, word)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, name)
+		assert.Equal(t, expectedLine, actualLine, name)
+		assert.Equal(t, expectedWords, actualWords, name)
+	}
+}
+
+func TestUTF8ReplaceWorksWithAcceptCR(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("caf\xe9\r\xffnext\r")
+		unit.SetUTF8Policy(UTF8Replace)
+		unit.SetLineEnding(AcceptCR)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		line1, err1 := unit.ReadLine()
+		line2, err2 := unit.ReadLine()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err1, name)
+		assert.Equal(t, "caf�", line1, name)
+		assert.Nil(t, err2, name)
+		assert.Equal(t, "�next", line2, name)
+	}
+}
+
+func TestUTF8ErrorReportsTheOffsetOfTheFirstInvalidByte(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("héllo\nbad \xff\n")
+		unit.SetUTF8Policy(UTF8Error)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		line1, err1 := unit.ReadLine()
+		_, err2 := unit.ReadLine()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err1, name)
+		assert.Equal(t, "héllo\n", line1, name)
+
+		assert.ErrorIs(t, err2, ErrInvalidByteSequence, name)
+		var encErr *EncodingError
+		if assert.True(t, errors.As(err2, &encErr), name) {
+			assert.Equal(t, int64(11), encErr.Offset, name)
+		}
+	}
+}
+
+func TestUTF8ErrorStopsReadLines(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("one\ntwo\nthr\xffee\nfour\n")
+		unit.SetUTF8Policy(UTF8Error)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		lines, errFn := unit.ReadLinesContext(context.Background())
+		actualResult := []string{}
+		for line := range lines {
+			actualResult = append(actualResult, line)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		// bufio.Scanner hands back the valid data in front of the
+		// invalid byte as a final line
+		assert.Equal(t, []string{"one", "two", "thr"}, actualResult, name)
+		assert.ErrorIs(t, errFn(), ErrInvalidByteSequence, name)
+	}
+}
+
+func TestUTF8ErrorReturnsTheValidDataBeforeTheError(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("hello \xff world")
+		unit.SetUTF8Policy(UTF8Error)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := io.ReadAll(unit)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.ErrorIs(t, err, ErrInvalidByteSequence, name)
+		assert.Equal(t, "hello ", string(actualResult), name)
+	}
+}
+
+// ================================================================
+//
+// Write methods
+//
+// ----------------------------------------------------------------
+
+func TestUTF8ReplaceReplacesInvalidDataOnWrite(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("")
+		unit.SetUTF8Policy(UTF8Replace)
+		var written bytes.Buffer
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		_, err := unit.WriteString("caf\xe9\n")
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, name)
+
+		switch u := unit.(type) {
+		case *TextBuffer:
+			written.Write(u.Buffer.Bytes())
+		case *TextFile:
+			u.Rewind()
+			io.Copy(&written, u.File)
+		case *TextIOWrapper:
+			io.Copy(&written, u.ReadWriteCloser)
+		}
+		assert.Equal(t, "caf�\n", written.String(), name)
+	}
+}
+
+func TestUTF8ErrorRejectsInvalidDataOnWrite(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("")
+		unit.SetUTF8Policy(UTF8Error)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		unit.WriteString("hello\n")
+		n, err := unit.WriteString("caf\xe9\n")
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, 3, n, name)
+		assert.ErrorIs(t, err, ErrInvalidByteSequence, name)
+		var encErr *EncodingError
+		if assert.True(t, errors.As(err, &encErr), name) {
+			assert.Equal(t, int64(9), encErr.Offset, name)
+		}
+	}
+}
+
+func TestUTF8ErrorRejectsInvalidRunes(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("")
+		unit.SetUTF8Policy(UTF8Error)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		n1, err1 := unit.WriteRune('é')
+		n2, err2 := unit.WriteRune(0xD800)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err1, name)
+		assert.Equal(t, 2, n1, name)
+		assert.Equal(t, 0, n2, name)
+		assert.ErrorIs(t, err2, ErrInvalidRune, name)
+	}
+}
+
+func TestUTF8ReplaceWritesInvalidRunesAsTheReplacementCharacter(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("")
+		unit.SetUTF8Policy(UTF8Replace)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		n, err := unit.WriteRune(0x110000)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, name)
+		assert.Equal(t, 3, n, name)
+	}
+}
+
+func TestTextBufferUTF8ReplaceReplacesAPartialCharacterAtTheEndOfAWrite(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.SetUTF8Policy(UTF8Replace)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	n, err := unit.Write([]byte("abc\xe2"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "abc�", unit.Buffer.String())
+}
+
+func TestTextBufferUTF8ErrorReportsAPartialCharacterAtTheEndOfAWrite(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.SetUTF8Policy(UTF8Error)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	n, err := unit.Write([]byte("abc\xe2"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, err, ErrInvalidByteSequence)
+	var encErr *EncodingError
+	if assert.True(t, errors.As(err, &encErr)) {
+		assert.Equal(t, int64(3), encErr.Offset)
+	}
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "abc", unit.Buffer.String())
+}
+
+func TestTextBufferUTF8PolicyReadFromKeepsCharactersSplitAcrossReads(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.SetUTF8Policy(UTF8Replace)
+	input := iotest.OneByteReader(strings.NewReader("€uro\xe2"))
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := unit.ReadFrom(input)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "€uro�", unit.Buffer.String())
+}
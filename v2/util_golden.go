@@ -0,0 +1,134 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UpdateGoldenFiles tells AssertGolden() (and friends) to rewrite the
+// golden file with the actual output, instead of comparing them.
+//
+// You can bind it to a command-line flag in your tests:
+//
+//	flag.BoolVar(&ioextra.UpdateGoldenFiles, "update", false, "update golden files")
+//
+// or set the environment variable named by UpdateGoldenFilesEnv.
+var UpdateGoldenFiles = false
+
+// UpdateGoldenFilesEnv is the environment variable that, when set to
+// anything other than an empty string, has the same effect as setting
+// UpdateGoldenFiles.
+const UpdateGoldenFilesEnv = "IOEXTRA_UPDATE_GOLDEN"
+
+// GoldenFileDir is the folder that AssertGolden() (and friends) look in
+// for golden files.
+const GoldenFileDir = "testdata"
+
+// AssertGolden compares `actual` against the contents of the golden file
+// `name` under GoldenFileDir (or at `name`, if it is an absolute path). If
+// they are different, it reports a unified diff via t.Errorf() and returns
+// false.
+//
+// If UpdateGoldenFiles (or UpdateGoldenFilesEnv) is set, it rewrites the
+// golden file with `actual` instead.
+func AssertGolden(t TestingT, name string, actual string) bool {
+	t.Helper()
+
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(GoldenFileDir, name)
+	}
+
+	if shouldUpdateGoldenFiles() {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(actual), 0644)
+		}
+		if err != nil {
+			t.Errorf("cannot update golden file %s: %v", path, err)
+			return false
+		}
+		return true
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("cannot read golden file %s: %v", path, err)
+		return false
+	}
+
+	diff := UnifiedDiff(path, "actual", string(expected), actual)
+	if diff != "" {
+		t.Errorf("output does not match golden file %s:\n%s", path, diff)
+		return false
+	}
+
+	return true
+}
+
+// AssertGoldenStrings compares `actual`, one line per array entry (eg,
+// from Strings()), against the contents of the golden file `name`.
+//
+// See AssertGolden() for details.
+func AssertGoldenStrings(t TestingT, name string, actual []string) bool {
+	t.Helper()
+
+	text := ""
+	if len(actual) > 0 {
+		text = strings.Join(actual, "\n") + "\n"
+	}
+
+	return AssertGolden(t, name, text)
+}
+
+// AssertGoldenTextBuffer compares the contents of `buf` against the
+// contents of the golden file `name`. It does not consume the contents
+// of `buf`.
+//
+// See AssertGolden() for details.
+func AssertGoldenTextBuffer(t TestingT, name string, buf *TextBuffer) bool {
+	t.Helper()
+
+	return AssertGolden(t, name, buf.Buffer.String())
+}
+
+// shouldUpdateGoldenFiles returns true if we have been asked to rewrite
+// our golden files.
+func shouldUpdateGoldenFiles() bool {
+	return UpdateGoldenFiles || os.Getenv(UpdateGoldenFilesEnv) != ""
+}
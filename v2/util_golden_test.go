@@ -0,0 +1,291 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTestingT is a helper. It records any errors reported by our
+// golden-file helpers.
+type fakeTestingT struct {
+	errors []string
+}
+
+func (f *fakeTestingT) Helper() {}
+
+func (f *fakeTestingT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// ================================================================
+//
+// AssertGolden
+//
+// ----------------------------------------------------------------
+
+func TestAssertGoldenPassesWhenTheOutputMatches(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fakeT := &fakeTestingT{}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	ok := AssertGolden(fakeT, "golden_example.txt", "hello world\nhave a nice day\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+	assert.Empty(t, fakeT.errors)
+}
+
+func TestAssertGoldenReportsAUnifiedDiffWhenTheOutputDoesNotMatch(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fakeT := &fakeTestingT{}
+	expectedDiff := "--- testdata/golden_example.txt\n" +
+		"+++ actual\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		" hello world\n" +
+		"-have a nice day\n" +
+		"+have a terrible day\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	ok := AssertGolden(fakeT, "golden_example.txt", "hello world\nhave a terrible day\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.False(t, ok)
+	assert.Len(t, fakeT.errors, 1)
+	assert.Contains(t, fakeT.errors[0], expectedDiff)
+}
+
+func TestAssertGoldenReportsMissingGoldenFiles(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fakeT := &fakeTestingT{}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	ok := AssertGolden(fakeT, "does_not_exist.txt", "hello world\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.False(t, ok)
+	assert.Len(t, fakeT.errors, 1)
+}
+
+func TestAssertGoldenRewritesTheGoldenFileWhenAskedTo(t *testing.T) {
+	// no t.Parallel(), because we change the environment
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	t.Setenv(UpdateGoldenFilesEnv, "1")
+	fakeT := &fakeTestingT{}
+	goldenFile := filepath.Join(t.TempDir(), "output.txt")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	ok := AssertGolden(fakeT, goldenFile, "hello world\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+	assert.Empty(t, fakeT.errors)
+
+	actualResult, err := os.ReadFile(goldenFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world\n", string(actualResult))
+}
+
+func TestAssertGoldenStringsComparesOneLinePerEntry(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fakeT := &fakeTestingT{}
+	buf := NewTextBuffer()
+	buf.WriteString("hello world\nhave a nice day\n")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	ok := AssertGoldenStrings(fakeT, "golden_example.txt", buf.Strings())
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+	assert.Empty(t, fakeT.errors)
+}
+
+func TestAssertGoldenTextBufferDoesNotConsumeTheBuffer(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fakeT := &fakeTestingT{}
+	buf := NewTextBuffer()
+	buf.WriteString("hello world\nhave a nice day\n")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	ok := AssertGoldenTextBuffer(fakeT, "golden_example.txt", buf)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, ok)
+	assert.Empty(t, fakeT.errors)
+	assert.Equal(t, "hello world\nhave a nice day\n", buf.String())
+}
+
+// ================================================================
+//
+// UnifiedDiff
+//
+// ----------------------------------------------------------------
+
+func TestUnifiedDiffReturnsEmptyStringForIdenticalText(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := UnifiedDiff("a", "b", "hello\n", "hello\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, "", actualResult)
+}
+
+func TestUnifiedDiffSplitsDistantChangesIntoSeparateHunks(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+	expectedResult := "--- a\n+++ b\n" +
+		"@@ -1,4 +1,4 @@\n" +
+		"-1\n+one\n 2\n 3\n 4\n" +
+		"@@ -10,3 +10,4 @@\n" +
+		" 10\n 11\n 12\n+13\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := UnifiedDiff("a", "b", a, b)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestUnifiedDiffHandlesEmptyInputs(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedResult := "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+hello\n+world\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := UnifiedDiff("a", "b", "", "hello\nworld\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestUnifiedDiffMarksAMissingNewlineAtTheEndOfTheFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedResult := "--- golden\n+++ actual\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		" hello\n" +
+		"-world\n" +
+		"\\ No newline at end of file\n" +
+		"+world\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := UnifiedDiff("golden", "actual", "hello\nworld", "hello\nworld\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
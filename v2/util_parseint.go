@@ -44,10 +44,21 @@ import (
 // If the underlying source contains anything other than a valid number,
 // an error is returned.
 func ParseInt(input LineReader) (int, error) {
-	text, err := input.ReadLine()
+	text, err := readTrimmedLine(input)
 	if err != nil {
 		return 0, err
 	}
 
-	return strconv.Atoi(strings.TrimSpace(text))
+	return strconv.Atoi(text)
+}
+
+// readTrimmedLine returns the next line in the given LineReader, with
+// any leading or trailing whitespace removed.
+func readTrimmedLine(input LineReader) (string, error) {
+	text, err := input.ReadLine()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(text), nil
 }
@@ -0,0 +1,116 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// parseTokens converts each token from the given iterator, using the
+// given parse function.
+//
+// Tokens that cannot be converted are returned with a *TokenError, and
+// the iteration carries on. Errors from the underlying iterator are
+// returned as-is, and end the iteration.
+func parseTokens[T any](
+	tokens iter.Seq2[string, error],
+	parse func(string) (T, error),
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		pos := 0
+		for token, err := range tokens {
+			var value T
+			if err != nil {
+				yield(value, err)
+				return
+			}
+
+			value, err = parse(token)
+			if err != nil {
+				err = &TokenError{Position: pos, Token: token, Err: err}
+			}
+			if !yield(value, err) {
+				return
+			}
+			pos++
+		}
+	}
+}
+
+// sendResults runs the given iterator in a new goroutine, and sends
+// each result down the channel that we return, until the given context
+// is cancelled.
+//
+// It also returns a function that tells you why the channel was closed:
+// the context's error if it was cancelled, any read error that ended the
+// iteration, or nil if we ran out of tokens.
+func sendResults[T any, R any](
+	ctx context.Context,
+	values iter.Seq2[T, error],
+	wrap func(T, error) R,
+) (<-chan R, func() error) {
+	chn := make(chan R)
+	done := make(chan struct{})
+	var readErr error
+
+	go func() {
+		defer close(done)
+		defer close(chn)
+
+		for value, err := range values {
+			var tokenErr *TokenError
+			if err != nil && !errors.As(err, &tokenErr) {
+				readErr = err
+			}
+
+			select {
+			case chn <- wrap(value, err):
+			case <-ctx.Done():
+				// breaking out stops the iterator too
+				readErr = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	errFn := func() error {
+		<-done
+		return readErr
+	}
+
+	return chn, errFn
+}
@@ -0,0 +1,227 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntsReturnsEachNumber(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("1 2 3\n-40\n50\n")
+	expectedResult := []int{1, 2, 3, -40, 50}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []int
+	for value, err := range Ints(reader) {
+		assert.Nil(t, err)
+		actualResult = append(actualResult, value)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestIntsReportsTheOffendingTokenAndPosition(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("1 2\nthree 4\n")
+	expectedResult := []int{1, 2, 4}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []int
+	var actualErrs []error
+	for value, err := range Ints(reader) {
+		if err != nil {
+			actualErrs = append(actualErrs, err)
+			continue
+		}
+		actualResult = append(actualResult, value)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Len(t, actualErrs, 1)
+
+	var tokenErr *TokenError
+	assert.True(t, errors.As(actualErrs[0], &tokenErr))
+	assert.Equal(t, 2, tokenErr.Position)
+	assert.Equal(t, "three", tokenErr.Token)
+	assert.True(t, errors.Is(actualErrs[0], strconv.ErrSyntax))
+}
+
+func TestReadIntsSendsEachResultDownTheChannel(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("1 x 3")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []IntResult
+	for result := range ReadInts(reader) {
+		actualResult = append(actualResult, result)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Len(t, actualResult, 3)
+	assert.Equal(t, IntResult{Value: 1}, actualResult[0])
+	assert.NotNil(t, actualResult[1].Err)
+	assert.Equal(t, IntResult{Value: 3}, actualResult[2])
+}
+
+func TestReadIntsContextStopsWhenTheContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := strings.NewReader("1 2 3 4 5")
+	chn, errFn := ReadIntsContext(ctx, reader)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	first := <-chn
+	cancel()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, IntResult{Value: 1}, first)
+
+	// if the goroutine did not stop, this would block forever
+	assert.ErrorIs(t, errFn(), context.Canceled)
+}
+
+func TestReadIntsContextReportsNoErrorWhenItRunsOutOfNumbers(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("1 x 3")
+	chn, errFn := ReadIntsContext(context.Background(), reader)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []int
+	for result := range chn {
+		actualResult = append(actualResult, result.Value)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	// the bad token is a *TokenError in its result, not a read error
+	assert.Equal(t, []int{1, 0, 3}, actualResult)
+	assert.Nil(t, errFn())
+}
+
+func TestReadFloatsContextStopsWhenTheContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	ctx, cancel := context.WithCancel(context.Background())
+	unit := NewTextBuffer()
+	unit.WriteString("1.5 2.5 3.5")
+	chn, errFn := unit.ReadFloatsContext(ctx)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	first := <-chn
+	cancel()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, FloatResult{Value: 1.5}, first)
+	assert.ErrorIs(t, errFn(), context.Canceled)
+}
+
+func TestFloatsReturnsEachNumber(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("1.5 -2 3e2")
+	expectedResult := []float64{1.5, -2, 300}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []float64
+	for value, err := range Floats(reader) {
+		assert.Nil(t, err)
+		actualResult = append(actualResult, value)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
@@ -0,0 +1,104 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// bytePeeker is an input source that can show us its next bytes without
+// consuming them (eg, bufio.Reader).
+type bytePeeker interface {
+	// Peek returns the next n bytes without consuming them. It returns
+	// fewer bytes, and an error, if n bytes are not available.
+	Peek(n int) ([]byte, error)
+
+	// Buffered returns how many bytes Peek() can return without reading
+	// any more data.
+	Buffered() int
+}
+
+// peekRune returns the next character from the given input source,
+// without consuming it.
+//
+// Like bufio.Reader.ReadRune(), an invalid or incomplete character is
+// returned as (utf8.RuneError, 1). We only wait for as many bytes as the
+// next character needs.
+func peekRune(input bytePeeker) (rune, int, error) {
+	for size := 1; ; size++ {
+		buf, err := input.Peek(size)
+		if len(buf) == 0 {
+			return 0, 0, err
+		}
+
+		if err != nil || utf8.FullRune(buf) {
+			r, n := utf8.DecodeRune(buf)
+			return r, n, nil
+		}
+	}
+}
+
+// peekLineWithEnding returns the next line from the given input source,
+// without consuming it. It returns the same line that ReadLineWithEnding()
+// would.
+//
+// We can only look as far ahead as the input source can buffer. If the
+// line is longer than that, we return as much of it as we can, along
+// with the input source's error (eg, bufio.ErrBufferFull).
+func peekLineWithEnding(input bytePeeker, ending LineEnding) (string, error) {
+	size := max(input.Buffered(), 1)
+	for {
+		buf, err := input.Peek(size)
+
+		i := bytes.IndexByte(buf, '\n')
+		if ending == AcceptCR {
+			if j := bytes.IndexByte(buf, '\r'); j >= 0 && (i < 0 || j < i) {
+				return string(buf[:j]), nil
+			}
+		}
+		if i >= 0 {
+			return ending.strip(string(buf[:i+1])), nil
+		}
+
+		if err != nil {
+			return ending.strip(string(buf)), err
+		}
+
+		// only ask for one more byte than we already have, so that we
+		// never wait for data that is not needed
+		size = max(input.Buffered(), len(buf)) + 1
+	}
+}
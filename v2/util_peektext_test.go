@@ -0,0 +1,441 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ================================================================
+//
+// Interface compatibility
+//
+// ----------------------------------------------------------------
+
+func TestTextWrappersImplementTextPeeker(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	units := []interface{}{
+		NewTextBuffer(),
+		&TextFile{},
+		NewTextIOWrapper(NopReadWriteCloser(&bytes.Buffer{})),
+		NewTextDevNull(),
+	}
+
+	for _, unit := range units {
+		// ----------------------------------------------------------------
+		// perform the change
+
+		_, ok := unit.(TextPeeker)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.True(t, ok)
+	}
+}
+
+// ================================================================
+//
+// ReadRune / UnreadRune
+//
+// ----------------------------------------------------------------
+
+func TestTextPeekerReadRuneReturnsEachCharacter(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("hé€😀")
+		expectedRunes := []rune{'h', 'é', '€', '😀'}
+		expectedSizes := []int{1, 2, 3, 4}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualRunes := []rune{}
+		actualSizes := []int{}
+		for {
+			r, size, err := unit.ReadRune()
+			if err != nil {
+				assert.Equal(t, io.EOF, err, name)
+				break
+			}
+			actualRunes = append(actualRunes, r)
+			actualSizes = append(actualSizes, size)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedRunes, actualRunes, name)
+		assert.Equal(t, expectedSizes, actualSizes, name)
+	}
+}
+
+func TestTextPeekerUnreadRunePutsTheLastCharacterBack(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("€uro\n")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		r1, _, err1 := unit.ReadRune()
+		err2 := unit.UnreadRune()
+		line, err3 := unit.ReadLine()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err1, name)
+		assert.Equal(t, '€', r1, name)
+		assert.Nil(t, err2, name)
+		assert.Nil(t, err3, name)
+		assert.Equal(t, "€uro\n", line, name)
+	}
+}
+
+func TestTextPeekerUnreadRuneFailsIfTheLastReadWasNotReadRune(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("one\ntwo\n")
+		unit.ReadLine()
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		err := unit.UnreadRune()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Error(t, err, name)
+	}
+}
+
+func TestTextPeekerCanMixReadRuneAndReadLine(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("# comment\nkey = value\n")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		r1, _, _ := unit.ReadRune()
+		line1, _ := unit.ReadLine()
+		r2, _, _ := unit.ReadRune()
+		line2, _ := unit.ReadLine()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, '#', r1, name)
+		assert.Equal(t, " comment\n", line1, name)
+		assert.Equal(t, 'k', r2, name)
+		assert.Equal(t, "ey = value\n", line2, name)
+	}
+}
+
+// ================================================================
+//
+// PeekRune
+//
+// ----------------------------------------------------------------
+
+func TestTextPeekerPeekRuneDoesNotConsumeTheCharacter(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("€uro")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		r1, size1, err1 := unit.PeekRune()
+		r2, size2, err2 := unit.PeekRune()
+		r3, size3, err3 := unit.ReadRune()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		for _, err := range []error{err1, err2, err3} {
+			assert.Nil(t, err, name)
+		}
+		for _, r := range []rune{r1, r2, r3} {
+			assert.Equal(t, '€', r, name)
+		}
+		for _, size := range []int{size1, size2, size3} {
+			assert.Equal(t, 3, size, name)
+		}
+	}
+}
+
+func TestTextPeekerPeekRuneReturnsEOFWhenThereIsNoMoreData(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("a")
+		unit.ReadRune()
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		_, size, err := unit.PeekRune()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, io.EOF, err, name)
+		assert.Equal(t, 0, size, name)
+	}
+}
+
+func TestTextPeekerPeekRuneReturnsRuneErrorForInvalidData(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("\xe2\x82")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		r, size, err := unit.PeekRune()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, name)
+		assert.Equal(t, utf8.RuneError, r, name)
+		assert.Equal(t, 1, size, name)
+	}
+}
+
+func TestTextPeekerPeekRuneFollowsTheUTF8Policy(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		replacing := newUnit("\xffa")
+		replacing.SetUTF8Policy(UTF8Replace)
+		failing := newUnit("a\xff")
+		failing.SetUTF8Policy(UTF8Error)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		r1, size1, err1 := replacing.PeekRune()
+		r2, _, err2 := failing.ReadRune()
+		_, _, err3 := failing.PeekRune()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err1, name)
+		assert.Equal(t, utf8.RuneError, r1, name)
+		assert.Equal(t, 3, size1, name)
+
+		assert.Nil(t, err2, name)
+		assert.Equal(t, 'a', r2, name)
+		assert.ErrorIs(t, err3, ErrInvalidByteSequence, name)
+	}
+}
+
+func TestTextIOWrapperPeekRuneDoesNotWaitForMoreDataThanItNeeds(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	client, server := NewTextPipe()
+	defer client.Close()
+	client.WriteString("y")
+
+	results := make(chan rune, 1)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	go func() {
+		r, _, _ := server.PeekRune()
+		results <- r
+	}()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	select {
+	case r := <-results:
+		assert.Equal(t, 'y', r)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "PeekRune() is waiting for data that it does not need")
+	}
+}
+
+// ================================================================
+//
+// PeekLine
+//
+// ----------------------------------------------------------------
+
+func TestTextPeekerPeekLineReturnsWhatReadLineWill(t *testing.T) {
+	t.Parallel()
+
+	testData := []struct {
+		ending   LineEnding
+		input    string
+		expected []string
+	}{
+		{KeepLineEnding, "one\r\ntwo\nthree", []string{"one\r\n", "two\n", "three"}},
+		{StripLF, "one\r\ntwo\nthree", []string{"one\r", "two", "three"}},
+		{StripCRLF, "one\r\ntwo\nthree", []string{"one", "two", "three"}},
+		{AcceptCR, "one\rtwo\r\nthree\nfour", []string{"one", "two", "three", "four"}},
+	}
+
+	for name, newUnit := range textWrappers() {
+		for _, testCase := range testData {
+			// ----------------------------------------------------------------
+			// setup your test
+
+			unit := newUnit(testCase.input)
+			unit.SetLineEnding(testCase.ending)
+
+			// ----------------------------------------------------------------
+			// perform the change
+
+			peeked := []string{}
+			read := []string{}
+			for {
+				peekedLine, peekErr := unit.PeekLine()
+				readLine, readErr := unit.ReadLine()
+
+				assert.Equal(t, readErr, peekErr, name)
+				peeked = append(peeked, peekedLine)
+				read = append(read, readLine)
+				if readErr != nil {
+					break
+				}
+			}
+
+			// ----------------------------------------------------------------
+			// test the results
+
+			assert.Equal(t, testCase.expected, read, name)
+			assert.Equal(t, read, peeked, name)
+		}
+	}
+}
+
+func TestTextPeekerPeekLineFollowsTheUTF8Policy(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("caf\xe9\n")
+		unit.SetUTF8Policy(UTF8Replace)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := unit.PeekLine()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, name)
+		assert.Equal(t, "caf�\n", actualResult, name)
+	}
+}
+
+func TestTextIOWrapperPeekLineReturnsErrBufferFullForLongLines(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	longLine := strings.Repeat("x", 5000) + "\n"
+	unit := NewTextIOWrapper(NopReadWriteCloser(bytes.NewBufferString(longLine)))
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	peeked, err := unit.PeekLine()
+	line, _ := unit.ReadLine()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, bufio.ErrBufferFull, err)
+	assert.Equal(t, longLine[:len(peeked)], peeked)
+	assert.Equal(t, longLine, line)
+}
@@ -0,0 +1,81 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// ReadAllString returns all the remaining data in the given io.Reader as
+// a single string.
+//
+// Unlike String(), it returns any read error instead of panicking. Any
+// data read before the error is still returned.
+func ReadAllString(input io.Reader) (string, error) {
+	retval, err := io.ReadAll(input)
+	return string(retval), err
+}
+
+// ReadAllStrings returns all of the remaining data in the given io.Reader
+// as an array of strings, one line per array entry.
+//
+// Unlike Strings(), it returns any read error, including any line that
+// was too long for the underlying bufio.Scanner. Any lines read before
+// the error are still returned.
+func ReadAllStrings(input io.Reader) ([]string, error) {
+	return collectStrings(ReadLinesContext(context.Background(), input))
+}
+
+// collectStrings drains the given channel into an array of strings,
+// and then returns the error from the given error function.
+func collectStrings(chn <-chan string, errFn func() error) ([]string, error) {
+	retval := []string{}
+	for line := range chn {
+		retval = append(retval, line)
+	}
+
+	return retval, errFn()
+}
+
+// ReadAllTrimmed returns all of the remaining data in the given io.Reader
+// as a string, with any leading or trailing whitespace removed.
+//
+// Unlike TrimmedString(), it returns any read error instead of panicking.
+func ReadAllTrimmed(input io.Reader) (string, error) {
+	retval, err := ReadAllString(input)
+	return strings.TrimSpace(retval), err
+}
@@ -0,0 +1,91 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"iter"
+	"strconv"
+)
+
+// ReadFloats returns a channel that you can `range` over to get each
+// remaining whitespace-separated number from the given io.Reader, as
+// a float64.
+//
+// If a token is not a valid number, its result contains a *TokenError,
+// and the channel carries on with the next token.
+//
+// If you might stop reading before the channel is closed, use
+// ReadFloatsContext() instead, so that you can stop its goroutine.
+func ReadFloats(input io.Reader) <-chan FloatResult {
+	chn, _ := ReadFloatsContext(context.Background(), input)
+	return chn
+}
+
+// ReadFloatsContext works just like ReadFloats(), but stops when the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed:
+// the context's error if it was cancelled, any read error that ended the
+// channel, or nil if there were no more numbers to read.
+func ReadFloatsContext(ctx context.Context, input io.Reader) (<-chan FloatResult, func() error) {
+	return floatResults(ctx, Floats(input))
+}
+
+// Floats returns an iterator that you can `range` over to get each
+// remaining whitespace-separated number from the given io.Reader, as
+// a float64.
+//
+// If a token is not a valid number, it is returned with a *TokenError,
+// and the iteration carries on with the next token. Read errors are
+// returned as-is, and end the iteration.
+func Floats(input io.Reader) iter.Seq2[float64, error] {
+	return parseTokens(NewTextIterator(input, bufio.ScanWords), parseFloat64)
+}
+
+// parseFloat64 converts a token into a float64.
+func parseFloat64(token string) (float64, error) {
+	return strconv.ParseFloat(token, 64)
+}
+
+// floatResults sends each value from the given iterator down a channel,
+// until the given context is cancelled.
+func floatResults(ctx context.Context, values iter.Seq2[float64, error]) (<-chan FloatResult, func() error) {
+	return sendResults(ctx, values, func(value float64, err error) FloatResult {
+		return FloatResult{Value: value, Err: err}
+	})
+}
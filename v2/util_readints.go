@@ -0,0 +1,86 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"iter"
+	"strconv"
+)
+
+// ReadInts returns a channel that you can `range` over to get each
+// remaining whitespace-separated number from the given io.Reader, as
+// an int.
+//
+// If a token is not a valid base 10 integer, its result contains a
+// *TokenError, and the channel carries on with the next token.
+//
+// If you might stop reading before the channel is closed, use
+// ReadIntsContext() instead, so that you can stop its goroutine.
+func ReadInts(input io.Reader) <-chan IntResult {
+	chn, _ := ReadIntsContext(context.Background(), input)
+	return chn
+}
+
+// ReadIntsContext works just like ReadInts(), but stops when the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed:
+// the context's error if it was cancelled, any read error that ended the
+// channel, or nil if there were no more numbers to read.
+func ReadIntsContext(ctx context.Context, input io.Reader) (<-chan IntResult, func() error) {
+	return intResults(ctx, Ints(input))
+}
+
+// Ints returns an iterator that you can `range` over to get each
+// remaining whitespace-separated number from the given io.Reader, as
+// an int.
+//
+// If a token is not a valid base 10 integer, it is returned with a
+// *TokenError, and the iteration carries on with the next token. Read
+// errors are returned as-is, and end the iteration.
+func Ints(input io.Reader) iter.Seq2[int, error] {
+	return parseTokens(NewTextIterator(input, bufio.ScanWords), strconv.Atoi)
+}
+
+// intResults sends each value from the given iterator down a channel,
+// until the given context is cancelled.
+func intResults(ctx context.Context, values iter.Seq2[int, error]) (<-chan IntResult, func() error) {
+	return sendResults(ctx, values, func(value int, err error) IntResult {
+		return IntResult{Value: value, Err: err}
+	})
+}
@@ -35,12 +35,40 @@
 package ioextra
 
 import (
-	"bufio"
 	"io"
 )
 
+// delimitedStringReader is implemented by buffered input sources, such as
+// bufio.Reader and bytes.Buffer.
+type delimitedStringReader interface {
+	ReadString(delim byte) (string, error)
+}
+
 // ReadLine returns the next line from the given io.Reader.
+//
+// If the io.Reader is already buffered (ie, it has a ReadString method),
+// we read the line from its buffer. Otherwise, we read one byte at a
+// time, so that we never consume any data past the end of the line.
 func ReadLine(input io.Reader) (string, error) {
-	reader := bufio.NewReader(input)
-	return reader.ReadString('\n')
+	// is this an input source that already buffers its data?
+	buffered, ok := input.(delimitedStringReader)
+	if ok {
+		return buffered.ReadString('\n')
+	}
+
+	// if we get here, we cannot read ahead without losing data
+	var retval []byte
+	b := make([]byte, 1)
+	for {
+		n, err := input.Read(b)
+		if n > 0 {
+			retval = append(retval, b[0])
+			if b[0] == '\n' {
+				return string(retval), nil
+			}
+		}
+		if err != nil {
+			return string(retval), err
+		}
+	}
 }
@@ -36,6 +36,7 @@ package ioextra
 
 import (
 	"bufio"
+	"context"
 	"io"
 )
 
@@ -44,3 +45,24 @@ import (
 func ReadLines(input io.Reader) <-chan string {
 	return NewTextScanner(input, bufio.ScanLines)
 }
+
+// ReadLinesContext returns a channel that you can `range` over to get each
+// remaining line from the given io.Reader, until the given context is
+// cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func ReadLinesContext(ctx context.Context, input io.Reader) (<-chan string, func() error) {
+	return NewTextScannerContext(ctx, input, bufio.ScanLines)
+}
+
+// ReadLinesWithOptions works just like ReadLinesContext(), but lets you
+// control how long each line can be, and what happens if a line is longer
+// than that.
+func ReadLinesWithOptions(
+	ctx context.Context,
+	input io.Reader,
+	opts ScanOptions,
+) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, input, bufio.ScanLines, opts)
+}
@@ -0,0 +1,80 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+)
+
+// ReadLineWithEnding returns the next line from the given input source,
+// handling the end of the line according to the given LineEnding.
+//
+// The input source must support io.ByteScanner (eg, bufio.Reader or
+// bytes.Buffer), so that we can look for a "\n" after a "\r" without
+// losing any data.
+func ReadLineWithEnding(input io.ByteScanner, ending LineEnding) (string, error) {
+	// the fast path
+	buffered, ok := input.(delimitedStringReader)
+	if ok && ending != AcceptCR {
+		line, err := buffered.ReadString('\n')
+		return ending.strip(line), err
+	}
+
+	var retval []byte
+	for {
+		b, err := input.ReadByte()
+		if err != nil {
+			return ending.strip(string(retval)), err
+		}
+
+		switch {
+		case b == '\n':
+			retval = append(retval, b)
+			return ending.strip(string(retval)), nil
+		case b == '\r' && ending == AcceptCR:
+			// swallow the "\n" of any "\r\n"
+			next, err := input.ReadByte()
+			if err == nil && next != '\n' {
+				err = input.UnreadByte()
+			}
+			if err != nil && err != io.EOF {
+				return string(retval), err
+			}
+			return string(retval), nil
+		default:
+			retval = append(retval, b)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// ReadParagraphs returns a channel that you can `range` over to get each
+// remaining paragraph (a block of lines, separated by blank lines) from
+// the given io.Reader.
+//
+// Each paragraph is sent as an array of lines, without their line
+// endings. Use ParagraphOptions to decide which lines count as blank, and
+// whether or not they are kept.
+func ReadParagraphs(input io.Reader, opts ParagraphOptions) <-chan []string {
+	chn, _ := ReadParagraphsContext(context.Background(), input, opts)
+	return chn
+}
+
+// ReadParagraphsContext returns a channel that you can `range` over to
+// get each remaining paragraph from the given io.Reader, until the given
+// context is cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func ReadParagraphsContext(
+	ctx context.Context,
+	input io.Reader,
+	opts ParagraphOptions,
+) (<-chan []string, func() error) {
+	return ReadParagraphsWithOptions(ctx, input, opts, ScanOptions{})
+}
+
+// ReadParagraphsWithOptions works just like ReadParagraphsContext(), but
+// lets you control how long each paragraph can be, and what happens if a
+// paragraph is longer than that.
+func ReadParagraphsWithOptions(
+	ctx context.Context,
+	input io.Reader,
+	opts ParagraphOptions,
+	scanOpts ScanOptions,
+) (<-chan []string, func() error) {
+	paragraphs, errFn := NewTextScannerWithOptions(ctx, input, NewParagraphSplitFunc(opts), scanOpts)
+
+	chn := make(chan []string)
+	go func() {
+		defer close(chn)
+
+		for paragraph := range paragraphs {
+			select {
+			case chn <- paragraphLines(paragraph):
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return chn, errFn
+}
+
+// paragraphLines splits a paragraph into its lines, removing their line
+// endings.
+func paragraphLines(paragraph string) []string {
+	retval := strings.Split(strings.TrimSuffix(paragraph, "\n"), "\n")
+	for i := range retval {
+		retval[i] = strings.TrimSuffix(retval[i], "\r")
+	}
+
+	return retval
+}
@@ -0,0 +1,227 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadParagraphsReturnsEachParagraphAsLines(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	input := strings.NewReader("Subject: hello\r\nFrom: alice\r\n\r\nline one\nline two\n")
+	expectedResult := [][]string{
+		{"Subject: hello", "From: alice"},
+		{"line one", "line two"},
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := [][]string{}
+	for paragraph := range ReadParagraphs(input, ParagraphOptions{}) {
+		actualResult = append(actualResult, paragraph)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestReadParagraphsCanKeepTheSeparatorLines(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	input := strings.NewReader("## v1.1\n\n  \n## v1.0\n")
+	opts := ParagraphOptions{
+		WhitespaceSeparators: true,
+		KeepSeparators:       true,
+	}
+	expectedResult := [][]string{
+		{"## v1.1", "", "  "},
+		{"## v1.0"},
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := [][]string{}
+	for paragraph := range ReadParagraphs(input, opts) {
+		actualResult = append(actualResult, paragraph)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestReadParagraphsContextStopsWhenTheContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	input := strings.NewReader(strings.Repeat("para\n\n", 100))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	chn, errFn := ReadParagraphsContext(ctx, input, ParagraphOptions{})
+	<-chn
+	cancel()
+	for range chn {
+		// drain the channel
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.ErrorIs(t, errFn(), context.Canceled)
+}
+
+func TestTextWrappersImplementParagraphsReader(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		var unit interface{} = newUnit("")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		_, ok := unit.(ParagraphsReader)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.True(t, ok, name)
+	}
+}
+
+func TestTextWrappersReadParagraphsReturnsEachParagraph(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("record: 1\nname: one\n\nrecord: 2\nname: two\n")
+		expectedResult := [][]string{
+			{"record: 1", "name: one"},
+			{"record: 2", "name: two"},
+		}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := [][]string{}
+		for paragraph := range unit.ReadParagraphs(ParagraphOptions{}) {
+			actualResult = append(actualResult, paragraph)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult, name)
+	}
+}
+
+func TestTextWrappersReadParagraphsJoinedReturnsEachParagraphAsAString(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("one\ntwo\n \nthree\n")
+		opts := ParagraphOptions{WhitespaceSeparators: true}
+		expectedResult := []string{"one\ntwo", "three"}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := []string{}
+		for paragraph := range unit.ReadParagraphsJoined(opts) {
+			actualResult = append(actualResult, paragraph)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult, name)
+	}
+}
+
+func TestTextWrappersReadParagraphsContextUsesTheScanOptions(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("short\n\n" + strings.Repeat("far too long\n", 10))
+		unit.SetScanOptions(ScanOptions{MaxTokenSize: 32})
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		chn, errFn := unit.ReadParagraphsContext(context.Background(), ParagraphOptions{})
+		actualResult := [][]string{}
+		for paragraph := range chn {
+			actualResult = append(actualResult, paragraph)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, [][]string{{"short"}}, actualResult, name)
+		assert.ErrorIs(t, errFn(), bufio.ErrTooLong, name)
+	}
+}
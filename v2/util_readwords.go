@@ -36,6 +36,7 @@ package ioextra
 
 import (
 	"bufio"
+	"context"
 	"io"
 )
 
@@ -44,3 +45,24 @@ import (
 func ReadWords(input io.Reader) <-chan string {
 	return NewTextScanner(input, bufio.ScanWords)
 }
+
+// ReadWordsContext returns a channel that you can `range` over to get each
+// remaining word from the given io.Reader, until the given context is
+// cancelled.
+//
+// It also returns a function that tells you why the channel was closed.
+// See NewTextScannerContext() for details.
+func ReadWordsContext(ctx context.Context, input io.Reader) (<-chan string, func() error) {
+	return NewTextScannerContext(ctx, input, bufio.ScanWords)
+}
+
+// ReadWordsWithOptions works just like ReadWordsContext(), but lets you
+// control how long each word can be, and what happens if a word is longer
+// than that.
+func ReadWordsWithOptions(
+	ctx context.Context,
+	input io.Reader,
+	opts ScanOptions,
+) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, input, bufio.ScanWords, opts)
+}
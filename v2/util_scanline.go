@@ -0,0 +1,240 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScanLine reads the next line from the given LineReader, splits it into
+// whitespace-separated fields, and decodes each field into the matching
+// destination.
+//
+// Each destination must be a pointer to a string, bool, int (of any
+// size), uint (of any size), float32, float64 or time.Duration, or to
+// anything that implements encoding.TextUnmarshaler. Integers are base
+// 10, unless they start with a 0x, 0o or 0b prefix. A leading zero on
+// its own does not make an integer octal.
+//
+// If a field cannot be decoded, a *FieldError is returned. If the line
+// does not have exactly one field per destination, an error wrapping
+// ErrFieldCount is returned.
+func ScanLine(input LineReader, dest ...interface{}) error {
+	text, err := readLineForScan(input)
+	if err != nil {
+		return err
+	}
+
+	return decodeFields(strings.Fields(text), dest)
+}
+
+// ScanLineDelimited reads the next line from the given LineReader, splits
+// it into fields wherever sep appears, and decodes each field into the
+// matching destination. Leading and trailing whitespace is removed from
+// each field before it is decoded.
+//
+// See ScanLine() for the supported destinations, and for the errors that
+// can be returned.
+func ScanLineDelimited(input LineReader, sep string, dest ...interface{}) error {
+	text, err := readLineForScan(input)
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Split(text, sep)
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	return decodeFields(fields, dest)
+}
+
+// readLineForScan returns the next line from the given LineReader,
+// without its line terminator.
+//
+// Unlike ReadLine(), a final line that has no line terminator is not
+// treated as an error.
+func readLineForScan(input LineReader) (string, error) {
+	text, err := input.ReadLine()
+	if err != nil && (err != io.EOF || text == "") {
+		return "", err
+	}
+
+	return strings.TrimRight(text, "\r\n"), nil
+}
+
+// decodeFields decodes each field into the matching destination.
+func decodeFields(fields []string, dest []interface{}) error {
+	if len(fields) != len(dest) {
+		return fmt.Errorf(
+			"%w: expected %d, got %d",
+			ErrFieldCount,
+			len(dest),
+			len(fields),
+		)
+	}
+
+	for i, field := range fields {
+		err := decodeField(field, dest[i])
+		if err != nil {
+			return &FieldError{Index: i, Field: field, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// decodeField decodes a single field into the given destination.
+//
+// The destination is only changed if the field decodes successfully.
+func decodeField(field string, dest interface{}) error {
+	switch d := dest.(type) {
+	case *string:
+		*d = field
+	case *bool:
+		v, err := strconv.ParseBool(field)
+		if err != nil {
+			return err
+		}
+		*d = v
+	case *int:
+		v, err := strconv.ParseInt(field, integerBase(field), strconv.IntSize)
+		if err != nil {
+			return err
+		}
+		*d = int(v)
+	case *int8:
+		v, err := strconv.ParseInt(field, integerBase(field), 8)
+		if err != nil {
+			return err
+		}
+		*d = int8(v)
+	case *int16:
+		v, err := strconv.ParseInt(field, integerBase(field), 16)
+		if err != nil {
+			return err
+		}
+		*d = int16(v)
+	case *int32:
+		v, err := strconv.ParseInt(field, integerBase(field), 32)
+		if err != nil {
+			return err
+		}
+		*d = int32(v)
+	case *int64:
+		v, err := strconv.ParseInt(field, integerBase(field), 64)
+		if err != nil {
+			return err
+		}
+		*d = v
+	case *uint:
+		v, err := strconv.ParseUint(field, integerBase(field), strconv.IntSize)
+		if err != nil {
+			return err
+		}
+		*d = uint(v)
+	case *uint8:
+		v, err := strconv.ParseUint(field, integerBase(field), 8)
+		if err != nil {
+			return err
+		}
+		*d = uint8(v)
+	case *uint16:
+		v, err := strconv.ParseUint(field, integerBase(field), 16)
+		if err != nil {
+			return err
+		}
+		*d = uint16(v)
+	case *uint32:
+		v, err := strconv.ParseUint(field, integerBase(field), 32)
+		if err != nil {
+			return err
+		}
+		*d = uint32(v)
+	case *uint64:
+		v, err := strconv.ParseUint(field, integerBase(field), 64)
+		if err != nil {
+			return err
+		}
+		*d = v
+	case *float32:
+		v, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return err
+		}
+		*d = float32(v)
+	case *float64:
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return err
+		}
+		*d = v
+	case *time.Duration:
+		v, err := time.ParseDuration(field)
+		if err != nil {
+			return err
+		}
+		*d = v
+	case encoding.TextUnmarshaler:
+		return d.UnmarshalText([]byte(field))
+	default:
+		return fmt.Errorf("unsupported destination type %T", dest)
+	}
+
+	return nil
+}
+
+// integerBase returns the base to parse the given integer field in.
+//
+// Fields that start with a 0x, 0o or 0b prefix get base 0, so that
+// strconv works the base out from the prefix. Everything else is base
+// 10, so that a leading zero (as in "08") does not turn the field into
+// an octal number.
+func integerBase(field string) int {
+	digits := strings.TrimLeft(field, "+-")
+	if len(digits) > 2 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X', 'o', 'O', 'b', 'B':
+			return 0
+		}
+	}
+
+	return 10
+}
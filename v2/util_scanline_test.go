@@ -0,0 +1,231 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanLineDecodesWhitespaceSeparatedFields(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("  640 480\tnearest 1.5 250ms\nhave a nice day\n")
+
+	var width int
+	var height uint16
+	var name string
+	var scale float64
+	var delay time.Duration
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := ScanLine(unit, &width, &height, &name, &scale, &delay)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 640, width)
+	assert.Equal(t, uint16(480), height)
+	assert.Equal(t, "nearest", name)
+	assert.Equal(t, 1.5, scale)
+	assert.Equal(t, 250*time.Millisecond, delay)
+
+	// prove that we only read one line
+	assert.Equal(t, "have a nice day\n", unit.String())
+}
+
+func TestScanLineDelimitedDecodesDelimitedFields(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("timeout = 30")
+
+	var key string
+	var value int
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := unit.ScanLineDelimited("=", &key, &value)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "timeout", key)
+	assert.Equal(t, 30, value)
+}
+
+func TestScanLineReportsWhichFieldFailed(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("640 wide\n")
+
+	var width int
+	var height int
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := unit.ScanLine(&width, &height)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, 1, fieldErr.Index)
+	assert.Equal(t, "wide", fieldErr.Field)
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+}
+
+func TestScanLineTreatsLeadingZerosAsBase10(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("08 09 0x1f 0b101\n")
+
+	var month int
+	var day uint8
+	var mask int
+	var flags uint
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := unit.ScanLine(&month, &day, &mask, &flags)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 8, month)
+	assert.Equal(t, uint8(9), day)
+	assert.Equal(t, 31, mask)
+	assert.Equal(t, uint(5), flags)
+}
+
+func TestScanLineLeavesTheDestinationAloneWhenAFieldFails(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("300 maybe\n")
+
+	small := int8(7)
+	ok := true
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := unit.ScanLine(&small, &ok)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+	assert.Equal(t, int8(7), small)
+	assert.True(t, ok)
+}
+
+func TestScanLineReportsWrongNumberOfFields(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("640\n")
+
+	var width int
+	var height int
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := unit.ScanLine(&width, &height)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, errors.Is(err, ErrFieldCount))
+}
+
+func TestScanLineReportsUnsupportedDestinations(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	unit := NewTextBuffer()
+	unit.WriteString("640\n")
+
+	var width complex128
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := unit.ScanLine(&width)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, 0, fieldErr.Index)
+}
@@ -0,0 +1,188 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+)
+
+// ScanNUL is a bufio.SplitFunc that returns each NUL-terminated record,
+// without its terminator. Use it to read the output of `find -print0`,
+// or anything else meant for `xargs -0`.
+//
+// The last record does not need a terminator.
+func ScanNUL(data []byte, atEOF bool) (int, []byte, error) {
+	return scanDelimited(data, atEOF, []byte{0})
+}
+
+// ScanCommas is a bufio.SplitFunc that returns each comma-separated
+// field. Line endings ("\n" or "\r\n") also end a field, so that you can
+// scan a whole file of comma-separated lines.
+//
+// Empty fields are returned as empty strings. Quoted fields are not
+// supported; use encoding/csv if you need them.
+func ScanCommas(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, ",\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, dropCR(data[:i]), nil
+		}
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), dropCR(data), nil
+	}
+
+	// request more data
+	return 0, nil, nil
+}
+
+// ScanParagraphs is a bufio.SplitFunc that returns each paragraph of
+// text. Paragraphs are separated by one or more empty lines.
+//
+// Each paragraph keeps the line endings between its lines, but not the
+// line ending of its last line.
+//
+// Use NewParagraphSplitFunc() if you need to change how paragraphs are
+// separated.
+func ScanParagraphs(data []byte, atEOF bool) (int, []byte, error) {
+	return ParagraphOptions{}.scan(data, atEOF)
+}
+
+// NewParagraphSplitFunc returns a bufio.SplitFunc that returns each
+// paragraph of text, as controlled by the given ParagraphOptions.
+//
+// Each paragraph keeps the line endings between its lines. Unless you
+// keep the separators, it does not keep the line ending of its last line.
+func NewParagraphSplitFunc(opts ParagraphOptions) bufio.SplitFunc {
+	return opts.scan
+}
+
+// NewDelimiterSplitFunc returns a bufio.SplitFunc that returns each
+// record that ends with `sep`, without the separator.
+//
+// The last record does not need a separator.
+func NewDelimiterSplitFunc(sep string) bufio.SplitFunc {
+	// robustness
+	if sep == "" {
+		panic("empty separator passed into ioextra.NewDelimiterSplitFunc()")
+	}
+
+	delim := []byte(sep)
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		return scanDelimited(data, atEOF, delim)
+	}
+}
+
+// NewRegexpSplitFunc returns a bufio.SplitFunc that returns each token
+// that is separated by a match of `re`. Matches that are empty are
+// ignored.
+//
+// The scanner only sees part of the input at a time. A match that reaches
+// the end of what it has seen so far is not used until the scanner has
+// read more data, so that `,\s*` (for example) matches all of the
+// whitespace after a comma.
+func NewRegexpSplitFunc(re *regexp.Regexp) bufio.SplitFunc {
+	// robustness
+	if re == nil {
+		panic("nil pointer passed into ioextra.NewRegexpSplitFunc()")
+	}
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		for _, loc := range re.FindAllIndex(data, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			if loc[1] == len(data) && !atEOF {
+				break
+			}
+			return loc[1], data[:loc[0]], nil
+		}
+		if atEOF {
+			return len(data), data, nil
+		}
+
+		// request more data
+		return 0, nil, nil
+	}
+}
+
+// ================================================================
+//
+// Helpers
+//
+// ----------------------------------------------------------------
+
+// scanDelimited returns the next token that ends with `delim`, without
+// the delimiter. The last token does not need a delimiter.
+func scanDelimited(data []byte, atEOF bool, delim []byte) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.Index(data, delim); i >= 0 {
+		return i + len(delim), data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	// request more data
+	return 0, nil, nil
+}
+
+// dropCR removes any trailing "\r" from the given data.
+func dropCR(data []byte) []byte {
+	if len(data) > 0 && data[len(data)-1] == '\r' {
+		return data[:len(data)-1]
+	}
+
+	return data
+}
+
+// dropLineEnding removes any trailing "\n" or "\r\n" from the given data.
+func dropLineEnding(data []byte) []byte {
+	if len(data) > 0 && data[len(data)-1] == '\n' {
+		return dropCR(data[:len(data)-1])
+	}
+
+	return data
+}
@@ -0,0 +1,422 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scanAll is a helper. It returns every token that `split` finds in
+// `input`, reading the input in one go, and then one byte at a time.
+func scanAll(t *testing.T, input string, split bufio.SplitFunc) []string {
+	t.Helper()
+
+	readers := []*strings.Reader{strings.NewReader(input), strings.NewReader(input)}
+	results := [][]string{}
+	for i, reader := range readers {
+		retval := []string{}
+		var chn <-chan string
+		if i == 0 {
+			chn = NewTextScanner(reader, split)
+		} else {
+			chn = NewTextScanner(iotest.OneByteReader(reader), split)
+		}
+		for token := range chn {
+			retval = append(retval, token)
+		}
+		results = append(results, retval)
+	}
+
+	assert.Equal(t, results[0], results[1], "one-byte reads gave different tokens")
+	return results[0]
+}
+
+// ================================================================
+//
+// ScanNUL
+//
+// ----------------------------------------------------------------
+
+func TestScanNULReturnsEachRecord(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "./a file\x00./dir/new\nline\x00./last"
+	expectedResult := []string{"./a file", "./dir/new\nline", "./last"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, ScanNUL)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestScanNULDoesNotReturnAnEmptyRecordAfterTheLastTerminator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "one\x00two\x00"
+	expectedResult := []string{"one", "two"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, ScanNUL)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+// ================================================================
+//
+// ScanCommas
+//
+// ----------------------------------------------------------------
+
+func TestScanCommasReturnsEachField(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "name,age\r\nalice,,30\nbob,42"
+	expectedResult := []string{"name", "age", "alice", "", "30", "bob", "42"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, ScanCommas)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+// ================================================================
+//
+// ScanParagraphs
+//
+// ----------------------------------------------------------------
+
+func TestScanParagraphsReturnsEachParagraph(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "\n\nfirst line\nsecond line\n\n\n\r\nnext\r\nparagraph\r\n\r\nlast\n\n"
+	expectedResult := []string{
+		"first line\nsecond line",
+		"next\r\nparagraph",
+		"last",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, ScanParagraphs)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestScanParagraphsReturnsTheLastParagraphWithoutAnEmptyLine(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "one\n\ntwo\nthree"
+	expectedResult := []string{"one", "two\nthree"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, ScanParagraphs)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestScanParagraphsReturnsNothingForBlankInput(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "\n\r\n\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, ScanParagraphs)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Empty(t, actualResult)
+}
+
+// ================================================================
+//
+// NewDelimiterSplitFunc
+//
+// ----------------------------------------------------------------
+
+func TestNewDelimiterSplitFuncSplitsOnTheGivenSeparator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "one::two:three::::four"
+	expectedResult := []string{"one", "two:three", "", "four"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, NewDelimiterSplitFunc("::"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestNewDelimiterSplitFuncPanicsOnAnEmptySeparator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Panics(t, func() { NewDelimiterSplitFunc("") })
+}
+
+// ================================================================
+//
+// NewRegexpSplitFunc
+//
+// ----------------------------------------------------------------
+
+func TestNewRegexpSplitFuncSplitsOnEachMatch(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "alpha,  beta;gamma ,\tdelta"
+	re := regexp.MustCompile(`\s*[,;]\s*`)
+	expectedResult := []string{"alpha", "beta", "gamma", "delta"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, NewRegexpSplitFunc(re))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestNewRegexpSplitFuncIgnoresEmptyMatches(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	testData := "a--b-c"
+	re := regexp.MustCompile(`-*`)
+	expectedResult := []string{"a", "b", "c"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := scanAll(t, testData, NewRegexpSplitFunc(re))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestNewRegexpSplitFuncPanicsOnANilRegexp(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Panics(t, func() { NewRegexpSplitFunc(nil) })
+}
+
+// ================================================================
+//
+// Text wrappers
+//
+// ----------------------------------------------------------------
+
+func TestTextWrappersReadDelimitedReturnsEachRecord(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("one|two||three")
+		expectedResult := []string{"one", "two", "", "three"}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := []string{}
+		for record := range unit.ReadDelimited("|") {
+			actualResult = append(actualResult, record)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult, name)
+	}
+}
+
+func TestTextWrappersReadRecordsUsesTheGivenSplitFunc(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("a\x00b c\x00")
+		expectedResult := []string{"a", "b c"}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := []string{}
+		for record := range unit.ReadRecords(ScanNUL) {
+			actualResult = append(actualResult, record)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult, name)
+	}
+}
+
+func TestTextWrappersReadRecordsContextUsesTheScanOptions(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("short\x00far too long\x00")
+		unit.SetScanOptions(ScanOptions{MaxTokenSize: 8})
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		chn, errFn := unit.ReadRecordsContext(context.Background(), ScanNUL)
+		actualResult := []string{}
+		for record := range chn {
+			actualResult = append(actualResult, record)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, []string{"short"}, actualResult, name)
+		assert.ErrorIs(t, errFn(), bufio.ErrTooLong, name)
+	}
+}
+
+func TestReadDelimitedReturnsEachRecord(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	input := strings.NewReader("a\tb\tc")
+	expectedResult := []string{"a", "b", "c"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := []string{}
+	for record := range ReadDelimited(input, "\t") {
+		actualResult = append(actualResult, record)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
@@ -40,11 +40,14 @@ import (
 
 // String returns all the remaining data in the given io.Reader as a
 // single string.
+//
+// It panics if the io.Reader returns an error. Use ReadAllString() if
+// you need the error instead.
 func String(input io.Reader) string {
-	retval, err := io.ReadAll(input)
+	retval, err := ReadAllString(input)
 	if err != nil {
 		panic(err)
 	}
 
-	return string(retval)
+	return retval
 }
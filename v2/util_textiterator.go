@@ -0,0 +1,170 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"io"
+	"iter"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NewTextIterator will run a bufio.Scanner over the contents of an
+// io.Reader, in the caller's goroutine.
+//
+// Just pass in your input source and one of the bufio scanner functions,
+// and then `range` over the iterator that we return. If the scan fails,
+// the final iteration returns an empty string and the error.
+//
+// Unlike NewTextScanner(), no goroutine is started, and nothing is
+// leaked if you `break` out of your loop early.
+func NewTextIterator(reader io.Reader, splitter bufio.SplitFunc) iter.Seq2[string, error] {
+	return NewTextIteratorWithOptions(reader, splitter, ScanOptions{})
+}
+
+// NewTextIteratorWithOptions works just like NewTextIterator(), but lets
+// you set the size of the scanner's buffer, and what happens when a
+// token is too large to fit in it.
+func NewTextIteratorWithOptions(
+	reader io.Reader,
+	splitter bufio.SplitFunc,
+	opts ScanOptions,
+) iter.Seq2[string, error] {
+	// robustness
+	if reader == nil {
+		panic("nil pointer passed into ioextra.NewTextIteratorWithOptions()")
+	}
+
+	return func(yield func(string, error) bool) {
+		scanner := bufio.NewScanner(reader)
+		opts.applyTo(scanner, splitter)
+
+		for scanner.Scan() {
+			if !yield(scanner.Text(), nil) {
+				return
+			}
+		}
+
+		err := scanner.Err()
+		if err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// withoutErrors turns an iter.Seq2 from NewTextIterator() into an
+// iter.Seq that simply stops at the first error.
+func withoutErrors(seq iter.Seq2[string, error]) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for text, err := range seq {
+			if err != nil || !yield(text) {
+				return
+			}
+		}
+	}
+}
+
+// newReadIterator returns an iterator that calls `next` to get each
+// token, until `next` returns an error.
+//
+// The text wrappers use this instead of NewTextIterator(), so that each
+// token is read straight from their own buffered reader. Nothing is
+// read ahead and lost if you `break` out of your loop early.
+func newReadIterator(next func() (string, error)) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for {
+			token, err := next()
+			if err == nil {
+				if !yield(token, nil) {
+					return
+				}
+				continue
+			}
+
+			// the last token does not need a terminator
+			if token != "" && !yield(token, nil) {
+				return
+			}
+			if err != io.EOF {
+				yield("", err)
+			}
+			return
+		}
+	}
+}
+
+// wordSource is what readWord() needs from its input source
+type wordSource interface {
+	io.ByteScanner
+	io.RuneScanner
+}
+
+// readWord returns the next whitespace-separated word from the given
+// input source. It consumes the whitespace in front of the word, and the
+// whitespace character that ends it, just like bufio.ScanWords.
+//
+// Invalid UTF-8 is returned untouched.
+func readWord(input wordSource) (string, error) {
+	var retval []byte
+	for {
+		b, err := input.ReadByte()
+		if err != nil {
+			return string(retval), err
+		}
+
+		r, size := rune(b), 1
+		if b >= utf8.RuneSelf {
+			err = input.UnreadByte()
+			if err == nil {
+				r, size, err = input.ReadRune()
+			}
+			if err != nil {
+				return string(retval), err
+			}
+		}
+
+		switch {
+		case unicode.IsSpace(r):
+			if len(retval) > 0 {
+				return string(retval), nil
+			}
+		case r == utf8.RuneError && size == 1:
+			retval = append(retval, b)
+		default:
+			retval = utf8.AppendRune(retval, r)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTextIteratorReturnsIterator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("hello world\nhave a nice day\n")
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for line, err := range NewTextIterator(reader, bufio.ScanLines) {
+		assert.Nil(t, err)
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestNewTextIteratorStopsCleanlyOnBreak(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("hello world\nhave a nice day\n")
+	expectedResult := []string{"hello", "world"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for word := range Words(reader) {
+		actualResult = append(actualResult, word)
+		if len(actualResult) == 2 {
+			break
+		}
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestNewTextIteratorReportsScannerErrors(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("hello\n" + strings.Repeat("x", bufio.MaxScanTokenSize+1))
+	expectedResult := []string{"hello", ""}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	var actualErr error
+	for line, err := range LinesWithErrors(reader) {
+		actualResult = append(actualResult, line)
+		actualErr = err
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+	assert.True(t, errors.Is(actualErr, bufio.ErrTooLong))
+}
+
+func TestLinesStopsAtTheFirstError(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	reader := strings.NewReader("hello\n" + strings.Repeat("x", bufio.MaxScanTokenSize+1))
+	expectedResult := []string{"hello"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	for line := range Lines(reader) {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+// ================================================================
+//
+// Text wrappers
+//
+// ----------------------------------------------------------------
+
+func TestTextWrappersLinesDoesNotLoseDataWhenYouBreakOut(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("a\nb\nc\n")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		var firstLine string
+		for line := range unit.Lines() {
+			firstLine = line
+			break
+		}
+		nextLine, err := unit.ReadLine()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, "a", firstLine, name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, "b\n", nextLine, name)
+	}
+}
+
+func TestTextWrappersWordsDoesNotLoseDataWhenYouBreakOut(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("  one two\nthree\n")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		var firstWord string
+		for word := range unit.Words() {
+			firstWord = word
+			break
+		}
+		nextLine, err := unit.ReadLine()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, "one", firstWord, name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, "two\n", nextLine, name)
+	}
+}
+
+func TestTextWrappersIntsDoesNotLoseDataWhenYouBreakOut(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("3\n10 20 30\n")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		var count int
+		for value := range unit.Ints() {
+			count = value
+			break
+		}
+		nextLine, err := unit.ReadLine()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, 3, count, name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, "10 20 30\n", nextLine, name)
+	}
+}
+
+func TestTextWrappersLinesFollowsTheLineEnding(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("one\r\ntwo\rthree\n\nfour")
+		unit.SetLineEnding(AcceptCR)
+		expectedResult := []string{"one", "two", "three", "", "four"}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := []string{}
+		for line := range unit.Lines() {
+			actualResult = append(actualResult, line)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult, name)
+	}
+}
+
+func TestTextWrappersWordsKeepsInvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	for name, newUnit := range textWrappers() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		unit := newUnit("caf\xe9 ol\u00e9\u00a0end")
+		expectedResult := []string{"caf\xe9", "ol\u00e9", "end"}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := []string{}
+		for word := range unit.Words() {
+			actualResult = append(actualResult, word)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult, name)
+	}
+}
@@ -0,0 +1,87 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+// pipeEnd is one endpoint of an in-memory pipe
+type pipeEnd struct {
+	in  *pipeBuffer
+	out *pipeBuffer
+}
+
+// Read returns data written by our peer.
+func (d *pipeEnd) Read(p []byte) (int, error) {
+	return d.in.Read(p)
+}
+
+// Write sends data to our peer.
+func (d *pipeEnd) Write(p []byte) (int, error) {
+	return d.out.Write(p)
+}
+
+// Close gives our peer io.EOF (once it has read everything that we have
+// written), and makes any further writes by our peer fail.
+func (d *pipeEnd) Close() error {
+	d.out.closeWriter()
+	d.in.closeReader()
+	return nil
+}
+
+// NewTextPipe creates an in-memory, bidirectional pipe, with unlimited
+// buffering in both directions. It returns both ends of the pipe.
+//
+// Anything written to one end can be read from the other. Closing one
+// end gives the other end io.EOF, once it has read everything that was
+// written before the Close().
+//
+// It is similar to net.Pipe(), but with full support for the TextReader
+// and TextWriter interfaces.
+func NewTextPipe() (*TextIOWrapper, *TextIOWrapper) {
+	return NewTextPipeWithOptions(PipeOptions{})
+}
+
+// NewTextPipeWithOptions creates an in-memory, bidirectional pipe, with
+// the buffering limits set in `opts`. It returns both ends of the pipe.
+//
+// See NewTextPipe() for details.
+func NewTextPipeWithOptions(opts PipeOptions) (*TextIOWrapper, *TextIOWrapper) {
+	aToB := newPipeBuffer(opts.LimitAToB)
+	bToA := newPipeBuffer(opts.LimitBToA)
+
+	a := NewTextIOWrapper(&pipeEnd{in: bToA, out: aToB})
+	b := NewTextIOWrapper(&pipeEnd{in: aToB, out: bToA})
+
+	// all done
+	return a, b
+}
@@ -0,0 +1,217 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTextPipeReturnsTwoConnectedEndpoints(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	a, b := NewTextPipe()
+
+	// this will not compile if our endpoints are not compatible
+	// with the TextReaderWriter interface
+	var rw TextReaderWriter = a
+	assert.NotNil(t, rw)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	a.WriteLine("ping")
+	ping, err1 := b.ReadLine()
+	b.WriteLine("pong")
+	pong, err2 := a.ReadLine()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Equal(t, "ping\n", ping)
+	assert.Nil(t, err2)
+	assert.Equal(t, "pong\n", pong)
+}
+
+func TestTextPipeCloseGivesThePeerEOF(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	a, b := NewTextPipe()
+	expectedResult := []string{"hello", "world"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	go func() {
+		a.WriteLine("hello")
+		a.WriteLine("world")
+		a.Close()
+	}()
+
+	actualResult := []string{}
+	for line := range b.ReadLines() {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestTextPipeWritesFailAfterThePeerCloses(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	a, b := NewTextPipe()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	b.Close()
+	_, err := a.WriteString("hello\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
+
+func TestTextPipeWritesBlockWhenTheBufferLimitIsReached(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	a, b := NewTextPipeWithOptions(PipeOptions{LimitAToB: 4})
+	done := make(chan struct{})
+
+	go func() {
+		a.WriteString("hello world\n")
+		close(done)
+	}()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var blocked bool
+	select {
+	case <-done:
+	case <-time.After(30 * time.Millisecond):
+		blocked = true
+	}
+
+	line, err := b.ReadLine()
+	<-done
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, blocked)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world\n", line)
+}
+
+func TestTextPipeBufferLimitsAreSetPerDirection(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	a, b := NewTextPipeWithOptions(PipeOptions{LimitAToB: 4})
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	// this would block forever if the limit applied to both directions
+	n, err := b.WriteString("hello world\n")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, 12, n)
+
+	line, _ := a.ReadLine()
+	assert.Equal(t, "hello world\n", line)
+}
+
+func TestTextPipeCanCarryAnExpecterConversation(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	ours, theirs := NewTextPipe()
+
+	// this is the 'CLI tool' that we are testing
+	go func() {
+		defer theirs.Close()
+		theirs.WriteString("Name: ")
+		name, _ := theirs.ReadLine()
+		theirs.WriteString("Hello, " + name)
+		theirs.WriteString("Bye!\n")
+	}()
+
+	unit := NewExpecter(ours)
+	defer unit.Close()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err1 := unit.Expect("Name: ", time.Second)
+	err2 := unit.SendLine("Stuart")
+	result, err3 := unit.Expect("Bye!", time.Second)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, "Hello, Stuart\n", result.Before)
+}
@@ -36,6 +36,7 @@ package ioextra
 
 import (
 	"bufio"
+	"context"
 	"io"
 )
 
@@ -43,24 +44,80 @@ import (
 //
 // Just pass in your input source and one of the bufio scanner functions,
 // and then `range` over the channel that we return.
+//
+// If you might stop ranging before the channel is closed, or you need to
+// know why the scan stopped, use NewTextScannerContext() instead.
 func NewTextScanner(reader io.Reader, splitter bufio.SplitFunc) <-chan string {
+	chn, _ := NewTextScannerContext(context.Background(), reader, splitter)
+	return chn
+}
+
+// NewTextScannerContext will run a bufio.Scanner over the contents of an
+// io.Reader, until either the input is exhausted or the given context is
+// cancelled.
+//
+// Just pass in your input source and one of the bufio scanner functions,
+// and then `range` over the channel that we return. The channel is
+// closed when the scan stops.
+//
+// The function that we return blocks until the scan has stopped, and then
+// returns the reason why: nil at the end of the input, ctx.Err() if the
+// context was cancelled, or the error reported by the bufio.Scanner (eg,
+// bufio.ErrTooLong, or an error from the underlying io.Reader). If you
+// stop ranging early, cancel the context before calling it.
+//
+// NOTE: cancelling the context cannot interrupt a Read() that is already
+// blocked inside the underlying io.Reader.
+func NewTextScannerContext(
+	ctx context.Context,
+	reader io.Reader,
+	splitter bufio.SplitFunc,
+) (<-chan string, func() error) {
+	return NewTextScannerWithOptions(ctx, reader, splitter, ScanOptions{})
+}
+
+// NewTextScannerWithOptions works just like NewTextScannerContext(), but
+// lets you set the size of the scanner's buffer, and what happens when a
+// token is too large to fit in it.
+func NewTextScannerWithOptions(
+	ctx context.Context,
+	reader io.Reader,
+	splitter bufio.SplitFunc,
+	opts ScanOptions,
+) (<-chan string, func() error) {
 	// robustness
 	if reader == nil {
-		panic("nil pointer passed into ioextra.NewScanReader()")
+		panic("nil pointer passed into ioextra.NewTextScannerWithOptions()")
 	}
 
 	chn := make(chan string)
+	done := make(chan struct{})
+	var scanErr error
 
 	scanner := bufio.NewScanner(reader)
-	scanner.Split(splitter)
+	opts.applyTo(scanner, splitter)
 
 	go func() {
-		for scanner.Scan() {
-			line := scanner.Text()
-			chn <- line
+		defer close(done)
+		defer close(chn)
+
+		for ctx.Err() == nil && scanner.Scan() {
+			select {
+			case chn <- scanner.Text():
+			case <-ctx.Done():
+			}
+		}
+
+		scanErr = ctx.Err()
+		if scanErr == nil {
+			scanErr = scanner.Err()
 		}
-		close(chn)
 	}()
 
-	return chn
+	errFn := func() error {
+		<-done
+		return scanErr
+	}
+
+	return chn, errFn
 }
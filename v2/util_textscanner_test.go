@@ -36,6 +36,8 @@ package ioextra
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -102,3 +104,87 @@ func TestNewTextScannerReturnsScannerUsingGivenSplitFunction(t *testing.T) {
 	assert.Equal(t, expectedLines, actualLines)
 	assert.Equal(t, expectedWords, actualWords)
 }
+
+func TestNewTextScannerContextReturnsNilErrorAtEndOfInput(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	buf := "hello world\nhave a nice day\n"
+	reader := strings.NewReader(buf)
+	expectedResult := []string{"hello world", "have a nice day"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	scanChn, scanErr := NewTextScannerContext(context.Background(), reader, bufio.ScanLines)
+
+	var actualResult []string
+	for line := range scanChn {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Nil(t, scanErr())
+}
+
+func TestNewTextScannerContextStopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	buf := strings.Repeat("hello world\n", 1000)
+	reader := strings.NewReader(buf)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	scanChn, scanErr := NewTextScannerContext(ctx, reader, bufio.ScanLines)
+
+	var actualResult []string
+	for line := range scanChn {
+		actualResult = append(actualResult, line)
+		cancel()
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	// the scanner may already be waiting to send the next line when
+	// we cancel the context, so we can get one extra line
+	assert.LessOrEqual(t, len(actualResult), 2)
+	assert.Equal(t, context.Canceled, scanErr())
+}
+
+func TestNewTextScannerContextReportsScannerErrors(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	buf := strings.Repeat("x", bufio.MaxScanTokenSize+1)
+	reader := strings.NewReader(buf)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	scanChn, scanErr := NewTextScannerContext(context.Background(), reader, bufio.ScanLines)
+
+	actualResult := []string{}
+	for line := range scanChn {
+		actualResult = append(actualResult, line)
+	}
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Empty(t, actualResult)
+	assert.True(t, errors.Is(scanErr(), bufio.ErrTooLong))
+}
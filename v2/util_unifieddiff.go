@@ -0,0 +1,215 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is how many unchanged lines UnifiedDiff() shows
+// around each change
+const diffContextLines = 3
+
+// diffOp is a single line in a diff
+type diffOp struct {
+	// kind is ' ' for unchanged lines, '-' for deleted lines, and '+'
+	// for inserted lines
+	kind byte
+
+	// line includes its line terminator, unless it is the last line
+	// of text that does not end in one
+	line string
+}
+
+// UnifiedDiff returns a line-oriented unified diff that turns `a` into
+// `b`, using `aName` and `bName` in the diff headers.
+//
+// It returns an empty string if `a` and `b` are the same. Like diff(1),
+// it marks a last line that has no line terminator with a
+// "\ No newline at end of file" line.
+func UnifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+
+	ops := diffLines(splitDiffLines(a), splitDiffLines(b))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", aName, bName)
+
+	// aPos[k] and bPos[k] are how many lines of `a` and `b` come before
+	// ops[k]
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+	for k, op := range ops {
+		aPos[k+1] = aPos[k]
+		bPos[k+1] = bPos[k]
+		if op.kind != '+' {
+			aPos[k+1]++
+		}
+		if op.kind != '-' {
+			bPos[k+1]++
+		}
+	}
+
+	k := 0
+	for k < len(ops) {
+		// skip to the next change
+		for k < len(ops) && ops[k].kind == ' ' {
+			k++
+		}
+		if k == len(ops) {
+			break
+		}
+
+		// find the end of this hunk, merging any changes that are
+		// close enough together
+		start := max(0, k-diffContextLines)
+		end := k
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContextLines {
+				end = min(len(ops), end+diffContextLines)
+				break
+			}
+			end = next
+		}
+
+		writeDiffHunk(&sb, ops[start:end], aPos[start], bPos[start], aPos[end]-aPos[start], bPos[end]-bPos[start])
+		k = end
+	}
+
+	return sb.String()
+}
+
+// writeDiffHunk writes a single hunk of a unified diff
+func writeDiffHunk(sb *strings.Builder, ops []diffOp, aStart, bStart, aCount, bCount int) {
+	// unified diffs count lines from 1, unless the range is empty
+	if aCount > 0 {
+		aStart++
+	}
+	if bCount > 0 {
+		bStart++
+	}
+
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+	for _, op := range ops {
+		sb.WriteByte(op.kind)
+		sb.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			sb.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// splitDiffLines splits the given text into lines, keeping their
+// line terminators so that a last line without one does not match
+// the same line with one
+func splitDiffLines(text string) []string {
+	retval := strings.SplitAfter(text, "\n")
+	if retval[len(retval)-1] == "" {
+		retval = retval[:len(retval)-1]
+	}
+
+	return retval
+}
+
+// diffLines works out the shortest list of edits that turns `a` into
+// `b`, using the longest common subsequence of their lines.
+func diffLines(a, b []string) []diffOp {
+	// the common prefix and suffix are usually most of the text, and
+	// trimming them keeps our LCS table small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	retval := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		retval = append(retval, diffOp{' ', line})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	// lcs[i][j] is the length of the LCS of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			retval = append(retval, diffOp{' ', midA[i]})
+			i++
+			j++
+		case j == len(midB) || (i < len(midA) && lcs[i+1][j] >= lcs[i][j+1]):
+			retval = append(retval, diffOp{'-', midA[i]})
+			i++
+		default:
+			retval = append(retval, diffOp{'+', midB[j]})
+			j++
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		retval = append(retval, diffOp{' ', line})
+	}
+
+	return retval
+}
@@ -0,0 +1,100 @@
+// ioextra is a library that adds helpful io stuff
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package ioextra
+
+import (
+	"io"
+	"iter"
+	"slices"
+)
+
+// WriteLines writes each of the given lines to the given io.Writer,
+// followed by DefaultLineTerminator.
+//
+// It returns the total number of bytes written, and the first error
+// encountered. It stops writing at the first error.
+func WriteLines(d io.Writer, lines []string) (int, error) {
+	return writeLines(d, slices.Values(lines), DefaultLineTerminator)
+}
+
+// WriteLinesFrom writes each line that it receives from the given
+// channel to the given io.Writer, followed by DefaultLineTerminator, until
+// the channel is closed.
+//
+// It returns the total number of bytes written, and the first error
+// encountered. After an error, it stops writing, but carries on
+// receiving until the channel is closed, so that the sender is never
+// left blocked.
+func WriteLinesFrom(d io.Writer, lines <-chan string) (int, error) {
+	return writeLines(d, drainingSeq(lines), DefaultLineTerminator)
+}
+
+// WriteLinesSeq writes each line from the given iterator to the given
+// io.Writer, followed by DefaultLineTerminator.
+//
+// It returns the total number of bytes written, and the first error
+// encountered. It stops the iterator at the first error.
+func WriteLinesSeq(d io.Writer, lines iter.Seq[string]) (int, error) {
+	return writeLines(d, lines, DefaultLineTerminator)
+}
+
+// writeLines writes each line from the given iterator to the given
+// io.Writer, followed by the given line terminator.
+func writeLines(d io.Writer, lines iter.Seq[string], terminator string) (int, error) {
+	retval := 0
+	for line := range lines {
+		n, err := WriteString(d, line+terminator)
+		retval += n
+		if err != nil {
+			return retval, err
+		}
+	}
+
+	return retval, nil
+}
+
+// drainingSeq returns an iterator over the given channel. If the
+// iteration is stopped early, the rest of the channel is thrown away.
+func drainingSeq(chn <-chan string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for line := range chn {
+			if !yield(line) {
+				for range chn {
+				}
+				return
+			}
+		}
+	}
+}
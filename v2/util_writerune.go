@@ -39,6 +39,8 @@ import "io"
 // WriteRune writes a single rune (a unicode character) to the underlying
 // file. It returns the number of types written, and any error encountered
 // that caused the write to file.
+//
+// Invalid runes are written as the unicode replacement character, U+FFFD.
 func WriteRune(d io.StringWriter, r rune) (int, error) {
 	return d.WriteString(string(r))
 }